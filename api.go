@@ -49,6 +49,19 @@ func requestForSteps(runner *testRunner) []string {
 	return make([]string, 0)
 }
 
+func registerRunnerSpecialParamResolvers(runner *testRunner) {
+	if runner == nil || runner.connection == nil || !runner.specialParamResolver {
+		return
+	}
+	//the runner is updated in place when it is restarted, so its resolvers use the connection to the restarted runner
+	prefixes, err := registerSpecialParamResolvers(&resolverConnection{connection: func() net.Conn { return runner.connection }})
+	if err != nil {
+		logger.ApiLog.Debug("Runner did not register special param prefixes: %s", err)
+		return
+	}
+	logger.ApiLog.Debug("Special param prefixes registered by runner: %v", prefixes)
+}
+
 func createGetStepNamesRequest() *gauge_messages.Message {
 	return &gauge_messages.Message{MessageType: gauge_messages.Message_StepNamesRequest.Enum(), StepNamesRequest: &gauge_messages.StepNamesRequest{}}
 }
@@ -41,18 +41,21 @@ type concept struct {
 type conceptParser struct {
 	currentState   int
 	currentConcept *step
+	fileName       string
 }
 
 //concept file can have multiple concept headings
-func (parser *conceptParser) parse(text string) ([]*step, *parseDetailResult) {
+func (parser *conceptParser) parse(text string) ([]*step, *parseResult) {
 	defer parser.resetState()
 
-	specParser := new(specParser)
-	tokens, err := specParser.generateTokens(text)
-	if err != nil {
-		return nil, &parseDetailResult{error: err}
+	specParser := &specParser{fileName: parser.fileName}
+	tokens, parseErrors := specParser.generateTokens(text)
+	concepts, result := parser.createConcepts(tokens)
+	if len(parseErrors) > 0 {
+		result.addErrors(parseErrors...)
+		return nil, result
 	}
-	return parser.createConcepts(tokens)
+	return concepts, result
 }
 
 func (parser *conceptParser) resetState() {
@@ -60,44 +63,70 @@ func (parser *conceptParser) resetState() {
 	parser.currentConcept = nil
 }
 
-func (parser *conceptParser) createConcepts(tokens []*token) ([]*step, *parseDetailResult) {
+//errors do not stop the parsing, so that all of them are reported at once. Steps of a concept whose
+//heading has errors are skipped, as they cannot belong to any other concept
+func (parser *conceptParser) createConcepts(tokens []*token) ([]*step, *parseResult) {
 	parser.currentState = initial
 	concepts := make([]*step, 0)
-	var parseDetails *parseDetailResult
+	result := &parseResult{ok: true}
 	preComments := make([]*comment, 0)
 	addPreComments := false
+	skipConcept := false
 	for _, token := range tokens {
 		if parser.isConceptHeading(token) {
 			if isInState(parser.currentState, conceptScope, stepScope) {
 				concepts = append(concepts, parser.currentConcept)
 			}
-			parser.currentConcept, parseDetails = parser.processConceptHeading(token)
+			concept, parseDetails := parser.processConceptHeading(token)
+			if parseDetails.warnings != nil {
+				result.warnings = append(result.warnings, parseDetails.warnings...)
+			}
 			if parseDetails.error != nil {
-				return nil, parseDetails
+				result.addErrors(parseDetails.error)
+				parser.currentConcept = nil
+				parser.currentState = initial
+				skipConcept = true
+				continue
 			}
+			parser.currentConcept = concept
+			skipConcept = false
 			if addPreComments {
 				parser.currentConcept.preComments = preComments
 				addPreComments = false
 			}
 			addStates(&parser.currentState, conceptScope)
+		} else if skipConcept {
+			continue
 		} else if parser.isStep(token) {
 			if !isInState(parser.currentState, conceptScope) {
-				return nil, &parseDetailResult{error: &parseError{lineNo: token.lineNo, message: "Step is not defined inside a concept heading", lineText: token.lineText}}
+				result.addErrors(&parseError{lineNo: token.lineNo, message: "Step is not defined inside a concept heading", lineText: token.lineText})
+				continue
 			}
 			if err := parser.processConceptStep(token); err != nil {
-				return nil, &parseDetailResult{error: err}
+				result.addErrors(err)
+				continue
 			}
 			addStates(&parser.currentState, stepScope)
 		} else if parser.isTableHeader(token) {
 			if !isInState(parser.currentState, stepScope) {
-				return nil, &parseDetailResult{error: &parseError{lineNo: token.lineNo, message: "Table doesn't belong to any step", lineText: token.lineText}}
+				result.addErrors(&parseError{lineNo: token.lineNo, message: "Table doesn't belong to any step", lineText: token.lineText})
+				continue
 			}
-			parser.processTableHeader(token)
+			parser.processTableHeader(token, &parser.currentConcept.lookup)
 			addStates(&parser.currentState, tableScope)
 		} else if parser.isTableDataRow(token) {
+			if !isInState(parser.currentState, tableScope) {
+				continue
+			}
 			parser.processTableDataRow(token, &parser.currentConcept.lookup)
+		} else if parser.isTextBlock(token) && isInState(parser.currentState, stepScope) {
+			parser.processTextBlock(token)
 		} else {
 			comment := &comment{value: token.value, lineNo: token.lineNo}
+			if parser.isTextBlock(token) {
+				comment.value = formatTextBlock(token.lineText, token.value)
+				result.warnings = append(result.warnings, &warning{"Text block not associated with a step, ignoring text block", token.lineNo})
+			}
 			if parser.currentConcept == nil {
 				preComments = append(preComments, comment)
 				addPreComments = true
@@ -106,14 +135,17 @@ func (parser *conceptParser) createConcepts(tokens []*token) ([]*step, *parseDet
 			parser.currentConcept.items = append(parser.currentConcept.items, comment)
 		}
 	}
-	if !isInState(parser.currentState, stepScope) && parser.currentState != initial {
-		return nil, &parseDetailResult{error: &parseError{lineNo: parser.currentConcept.lineNo, message: "Concept should have atleast one step", lineText: parser.currentConcept.lineText}}
+	if !isInState(parser.currentState, stepScope) && parser.currentState != initial && len(result.errors) == 0 {
+		result.addErrors(&parseError{lineNo: parser.currentConcept.lineNo, message: "Concept should have atleast one step", lineText: parser.currentConcept.lineText})
+	}
+	if len(result.errors) > 0 {
+		return nil, result
 	}
 
 	if parser.currentConcept != nil {
 		concepts = append(concepts, parser.currentConcept)
 	}
-	return concepts, parseDetails
+	return concepts, result
 }
 
 func (parser *conceptParser) isConceptHeading(token *token) bool {
@@ -132,12 +164,16 @@ func (parser *conceptParser) isTableDataRow(token *token) bool {
 	return token.kind == tableRow
 }
 
+func (parser *conceptParser) isTextBlock(token *token) bool {
+	return token.kind == textBlockKind
+}
+
 func (parser *conceptParser) processConceptHeading(token *token) (*step, *parseDetailResult) {
 	processStep(new(specParser), token)
 	token.lineText = strings.TrimSpace(strings.TrimLeft(strings.TrimSpace(token.lineText), "#"))
 	var concept *step
 	var parseDetails *parseDetailResult
-	concept, parseDetails = new(specification).createStepUsingLookup(token, nil)
+	concept, parseDetails = (&specification{fileName: parser.fileName}).createStepUsingLookup(token, nil)
 	if parseDetails != nil && parseDetails.error != nil {
 		return nil, parseDetails
 	}
@@ -154,7 +190,7 @@ func (parser *conceptParser) processConceptHeading(token *token) (*step, *parseD
 
 func (parser *conceptParser) processConceptStep(token *token) *parseError {
 	processStep(new(specParser), token)
-	conceptStep, parseDetails := new(specification).createStepUsingLookup(token, &parser.currentConcept.lookup)
+	conceptStep, parseDetails := (&specification{fileName: parser.fileName}).createStepUsingLookup(token, &parser.currentConcept.lookup)
 	if parseDetails != nil && parseDetails.error != nil {
 		return parseDetails.error
 	}
@@ -167,10 +203,10 @@ func (parser *conceptParser) processConceptStep(token *token) *parseError {
 	return nil
 }
 
-func (parser *conceptParser) processTableHeader(token *token) {
+func (parser *conceptParser) processTableHeader(token *token, argLookup *argLookup) {
 	steps := parser.currentConcept.conceptSteps
 	currentStep := steps[len(steps)-1]
-	addInlineTableHeader(currentStep, token)
+	addInlineTableHeader(currentStep, token, argLookup)
 	items := parser.currentConcept.items
 	items[len(items)-1] = currentStep
 }
@@ -183,6 +219,14 @@ func (parser *conceptParser) processTableDataRow(token *token, argLookup *argLoo
 	items[len(items)-1] = currentStep
 }
 
+func (parser *conceptParser) processTextBlock(token *token) {
+	steps := parser.currentConcept.conceptSteps
+	currentStep := steps[len(steps)-1]
+	addTextBlock(currentStep, token)
+	items := parser.currentConcept.items
+	items[len(items)-1] = currentStep
+}
+
 func (parser *conceptParser) hasOnlyDynamicParams(step *step) bool {
 	for _, arg := range step.args {
 		if arg.argType != "dynamic" {
@@ -197,37 +241,45 @@ func (parser *conceptParser) createConceptLookup(concept *step) {
 		concept.lookup.addArgName(arg.value)
 	}
 }
-func createConceptsDictionary(shouldIgnoreErrors bool) (*conceptDictionary, *parseResult) {
+
+//every concept file is parsed even if some of them fail, so that the errors of all the files are reported together.
+//The dictionary holds the concepts of the files that parsed, so that the specs can be parsed and their errors reported too
+func createConceptsDictionary(shouldIgnoreErrors bool) (*conceptDictionary, []*parseResult) {
 	conceptFiles := util.FindConceptFilesIn(filepath.Join(config.ProjectRoot, common.SpecsDirectoryName))
 	conceptsDictionary := newConceptDictionary()
+	parseResults := make([]*parseResult, 0)
 	for _, conceptFile := range conceptFiles {
-		if err := addConcepts(conceptFile, conceptsDictionary); err != nil {
+		if result := addConcepts(conceptFile, conceptsDictionary); !result.ok {
 			if shouldIgnoreErrors {
-				logger.ApiLog.Error("Concept parse failure: %s %s", conceptFile, err)
+				logger.ApiLog.Error("Concept parse failure: %s", result.Error())
 				continue
 			}
-			logger.Log.Error(err.Error())
-			return nil, &parseResult{error: err, fileName: conceptFile}
+			parseResults = append(parseResults, result)
 		}
 	}
-	return conceptsDictionary, &parseResult{ok: true}
+	if len(parseResults) > 0 {
+		return conceptsDictionary, parseResults
+	}
+	return conceptsDictionary, []*parseResult{&parseResult{ok: true}}
 }
 
-func addConcepts(conceptFile string, conceptDictionary *conceptDictionary) *parseError {
+func addConcepts(conceptFile string, conceptDictionary *conceptDictionary) *parseResult {
 	fileText, fileReadErr := common.ReadFileContents(conceptFile)
 	if fileReadErr != nil {
-		return &parseError{message: fmt.Sprintf("failed to read concept file %s", conceptFile)}
+		return &parseResult{error: &parseError{message: fmt.Sprintf("failed to read concept file %s", conceptFile)}, fileName: conceptFile}
 	}
-	concepts, parseResults := new(conceptParser).parse(fileText)
-	if parseResults != nil && parseResults.warnings != nil {
-		for _, warning := range parseResults.warnings {
-			logger.Log.Warning(warning.String())
-		}
+	concepts, result := (&conceptParser{fileName: conceptFile}).parse(fileText)
+	result.fileName = conceptFile
+	for _, warning := range result.warnings {
+		logger.Log.Warning(warning.String())
+	}
+	if !result.ok {
+		return result
 	}
-	if parseResults != nil && parseResults.error != nil {
-		return parseResults.error
+	if err := conceptDictionary.add(concepts, conceptFile); err != nil {
+		result.addErrors(err)
 	}
-	return conceptDictionary.add(concepts, conceptFile)
+	return result
 }
 
 func newConceptDictionary() *conceptDictionary {
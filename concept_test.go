@@ -18,7 +18,10 @@
 package main
 
 import (
+	"github.com/getgauge/gauge/config"
 	. "gopkg.in/check.v1"
+	"os"
+	"path/filepath"
 	"strings"
 )
 
@@ -298,6 +301,21 @@ func (s *MySuite) TestParsingSimpleConcept(c *C) {
 
 }
 
+func (s *MySuite) TestParsingConceptStepWithTextBlock(c *C) {
+	parser := new(conceptParser)
+	concepts, parseRes := parser.parse("# my concept\n* first step\n```json\n{\"a\": 1}\n```\n* second step\n")
+
+	c.Assert(parseRes.ok, Equals, true)
+	c.Assert(len(concepts[0].conceptSteps), Equals, 2)
+
+	firstStep := concepts[0].conceptSteps[0]
+	c.Assert(firstStep.value, Equals, "first step {}")
+	c.Assert(len(firstStep.args), Equals, 1)
+	c.Assert(firstStep.args[0].argType, Equals, specialString)
+	c.Assert(firstStep.args[0].value, Equals, "{\"a\": 1}")
+	c.Assert(len(concepts[0].conceptSteps[1].args), Equals, 0)
+}
+
 func (s *MySuite) TestErrorParsingConceptHeadingWithStaticOrSpecialParameter(c *C) {
 	parser := new(conceptParser)
 	_, parseRes := parser.parse("# my concept with \"paratemer\" \n * first step \n * second step ")
@@ -444,6 +462,34 @@ func (s *MySuite) TestErrorParsingConceptWithInvalidInlineTable(c *C) {
 	c.Assert(parseRes.error.message, Equals, "Table doesn't belong to any step")
 }
 
+func (s *MySuite) TestCollectingAllErrorsInConceptFile(c *C) {
+	parser := new(conceptParser)
+	_, parseRes := parser.parse("* step outside concept\n# my concept <foo>\n* step with <bar>\n* step \"unterminated\n# another concept\n* valid step\n")
+
+	c.Assert(parseRes.ok, Equals, false)
+	c.Assert(len(parseRes.errors), Equals, 3)
+	c.Assert(parseRes.errors[0].Error(), Equals, "line no: 1, Step is not defined inside a concept heading")
+	c.Assert(parseRes.errors[1].Error(), Equals, "line no: 3, Dynamic parameter <bar> could not be resolved")
+	c.Assert(parseRes.errors[2].Error(), Equals, "line no: 4, String not terminated")
+}
+
+func (s *MySuite) TestConceptsDictionaryHoldsConceptsOfFilesWhichParsed(c *C) {
+	projectRoot := createSpecialParamProject(c, map[string]string{
+		"project/specs/good.cpt": "# good concept\n* a step\n",
+		"project/specs/bad.cpt":  "* step outside concept\n",
+	})
+	defer os.RemoveAll(filepath.Dir(projectRoot))
+	oldProjectRoot := config.ProjectRoot
+	config.ProjectRoot = projectRoot
+	defer func() { config.ProjectRoot = oldProjectRoot }()
+
+	dictionary, parseResults := createConceptsDictionary(false)
+
+	c.Assert(len(parseResults), Equals, 1)
+	c.Assert(parseResults[0].ok, Equals, false)
+	c.Assert(dictionary.search("good concept"), NotNil)
+}
+
 func (s *MySuite) TestDeepCopyOfConcept(c *C) {
 	dictionary := new(conceptDictionary)
 	normalStep1 := &step{value: "normal step 1", lineText: "normal step 1"}
@@ -729,3 +775,16 @@ func (s *MySuite) TestConceptHavingStaticParameters(c *C) {
 	_, parseRes := new(conceptParser).parse(conceptText)
 	c.Assert(parseRes.error.message, Equals, "Concept heading can have only Dynamic Parameters")
 }
+
+func (s *MySuite) TestConceptStepWithVerticalTable(c *C) {
+	conceptText := SpecBuilder().specHeading("create user <name>").step("add user").text("orientation: vertical").tableHeader("name", "<name>").tableRow("age", "20").String()
+
+	concepts, result := new(conceptParser).parse(conceptText)
+
+	c.Assert(result.ok, Equals, true)
+	table := concepts[0].conceptSteps[0].args[0].table
+	c.Assert(table.isVertical, Equals, true)
+	c.Assert(table.headers, DeepEquals, []string{"name", "age"})
+	c.Assert(table.get("name")[0].cellType, Equals, dynamic)
+	c.Assert(table.get("age")[0].value, Equals, "20")
+}
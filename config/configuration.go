@@ -26,12 +26,16 @@ import (
 )
 
 const (
-	gaugeRepositoryUrl      = "gauge_repository_url"
-	apiRefreshInterval      = "api_refresh_interval"
-	runnerConnectionTimeout = "runner_connection_timeout"
-	pluginConnectionTimeout = "plugin_connection_timeout"
-	pluginKillTimeOut       = "plugin_kill_timeout"
-	runnerRequestTimeout    = "runner_request_timeout"
+	gaugeRepositoryUrl               = "gauge_repository_url"
+	apiRefreshInterval               = "api_refresh_interval"
+	runnerConnectionTimeout          = "runner_connection_timeout"
+	pluginConnectionTimeout          = "plugin_connection_timeout"
+	pluginKillTimeOut                = "plugin_kill_timeout"
+	runnerRequestTimeout             = "runner_request_timeout"
+	stepTimeout                      = "step_timeout"
+	scenarioTimeout                  = "scenario_timeout"
+	allowSpecialParamsOutsideProject = "allow_special_params_outside_project"
+	interpolateEnvVariables          = "interpolate_env_variables"
 
 	defaultApiRefreshInterval      = time.Second * 3
 	defaultRunnerConnectionTimeout = time.Second * 25
@@ -39,6 +43,8 @@ const (
 	defaultPluginKillTimeout       = time.Second * 4
 	defaultRefactorTimeout         = time.Second * 10
 	defaultRunnerRequestTimeout    = time.Second * 3
+	defaultStepTimeout             = 0
+	defaultScenarioTimeout         = 0
 	LayoutForTimeStamp             = "Jan 2, 2006 at 3:04pm"
 )
 
@@ -82,6 +88,46 @@ func RunnerRequestTimeout() time.Duration {
 	return convertToTime(intervalString, defaultRunnerRequestTimeout, runnerRequestTimeout)
 }
 
+// Timeout in milliseconds for executing a single step. Steps are not timed out if it is not set.
+func StepTimeout() time.Duration {
+	return getTimeout(stepTimeout, defaultStepTimeout)
+}
+
+// Timeout in milliseconds for executing all the steps of a scenario. Scenarios are not timed out if it is not set.
+func ScenarioTimeout() time.Duration {
+	return getTimeout(scenarioTimeout, defaultScenarioTimeout)
+}
+
+// Converts the timeout set in milliseconds for the given property, defaulting when the property is not set.
+func getTimeout(propertyName string, defaultValue time.Duration) time.Duration {
+	intervalString := getFromConfig(propertyName)
+	if intervalString == "" {
+		return defaultValue
+	}
+	return convertToTime(intervalString, defaultValue, propertyName)
+}
+
+// Whether files outside the project can be loaded by special params like <file:> and <table:>. Not allowed if it is not set.
+func AllowSpecialParamsOutsideProject() bool {
+	value := os.Getenv(allowSpecialParamsOutsideProject)
+	if value == "" {
+		value = getFromConfig(allowSpecialParamsOutsideProject)
+	}
+	allowed, err := strconv.ParseBool(value)
+	return err == nil && allowed
+}
+
+// Whether ${NAME} in step parameters and table cells is replaced with the value of the environment variable NAME.
+// Only <env:NAME> params are resolved if it is not set, so that step parameters can have ${ in them.
+func InterpolateEnvVariables() bool {
+	value := os.Getenv(interpolateEnvVariables)
+	if value == "" {
+		value = getFromConfig(interpolateEnvVariables)
+	}
+	interpolate, err := strconv.ParseBool(value)
+	return err == nil && interpolate
+}
+
 func GaugeRepositoryUrl() string {
 	return getFromConfig(gaugeRepositoryUrl)
 }
@@ -21,6 +21,7 @@ import (
 	. "gopkg.in/check.v1"
 	"os"
 	"testing"
+	"time"
 )
 
 func Test(t *testing.T) { TestingT(t) }
@@ -47,3 +48,34 @@ func (s *MySuite) TestRunnerRequestTimeout(c *C) {
 	os.Setenv(runnerRequestTimeout, "1000")
 	c.Assert(RunnerRequestTimeout().Seconds(), Equals, float64(1))
 }
+
+func (s *MySuite) TestStepAndScenarioTimeout(c *C) {
+	getFromConfig = stubGetFromConfig
+	c.Assert(StepTimeout(), Equals, time.Duration(0))
+	c.Assert(ScenarioTimeout(), Equals, time.Duration(0))
+
+	getFromConfig = stub2GetFromConfig
+	c.Assert(StepTimeout().Seconds(), Equals, float64(10))
+	c.Assert(ScenarioTimeout().Seconds(), Equals, float64(10))
+}
+
+func (s *MySuite) TestAllowSpecialParamsOutsideProject(c *C) {
+	getFromConfig = stubGetFromConfig
+	c.Assert(AllowSpecialParamsOutsideProject(), Equals, false)
+
+	getFromConfig = func(propertyName string) string { return "true" }
+	c.Assert(AllowSpecialParamsOutsideProject(), Equals, true)
+
+	os.Setenv(allowSpecialParamsOutsideProject, "false")
+	defer os.Unsetenv(allowSpecialParamsOutsideProject)
+	c.Assert(AllowSpecialParamsOutsideProject(), Equals, false)
+}
+
+func (s *MySuite) TestInterpolateEnvVariables(c *C) {
+	getFromConfig = stubGetFromConfig
+	c.Assert(InterpolateEnvVariables(), Equals, false)
+
+	os.Setenv(interpolateEnvVariables, "true")
+	defer os.Unsetenv(interpolateEnvVariables)
+	c.Assert(InterpolateEnvVariables(), Equals, true)
+}
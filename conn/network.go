@@ -30,6 +30,9 @@ import (
 	"time"
 )
 
+// Returned by GetResponseForMessageWithTimeout when no response arrives in time
+var ErrRequestTimeout = errors.New("Request Timeout")
+
 func WriteDataAndGetResponse(conn net.Conn, messageBytes []byte) ([]byte, error) {
 	if err := Write(conn, messageBytes); err != nil {
 		return nil, err
@@ -127,7 +130,7 @@ func GetResponseForMessageWithTimeout(message *gauge_messages.Message, conn net.
 	case <-responseChan:
 		return response, nil
 	case <-time.After(t):
-		return nil, errors.New("Request Timeout")
+		return nil, ErrRequestTimeout
 	}
 }
 
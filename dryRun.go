@@ -0,0 +1,121 @@
+// Copyright 2015 ThoughtWorks, Inc.
+
+// This file is part of Gauge.
+
+// Gauge is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+
+// Gauge is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+
+// You should have received a copy of the GNU General Public License
+// along with Gauge.  If not, see <http://www.gnu.org/licenses/>.
+
+package main
+
+import (
+	"fmt"
+	"github.com/getgauge/gauge/gauge_messages"
+)
+
+type dryRunError struct {
+	fileName string
+	scenario string
+	message  string
+}
+
+func (e *dryRunError) Error() string {
+	return fmt.Sprintf("%s : %s : %s", e.fileName, e.scenario, e.message)
+}
+
+// Resolves every scenario (and every selected data table row) of the given specs
+// and prints the resolved steps without sending them to the runner.
+// Returns the exit code for the run.
+func dryRunSpecs(specs []*specification, writer executionLogger) int {
+	dryRunErrors := make([]error, 0)
+	for _, spec := range specs {
+		executor := newSpecExecutor(spec, nil, nil, writer, getDataTableRows(spec))
+		dryRunErrors = append(dryRunErrors, executor.dryRun()...)
+	}
+	if len(dryRunErrors) > 0 {
+		writer.Error("\nDry run failed. The following scenarios could not be resolved:\n")
+		for _, err := range dryRunErrors {
+			writer.PrintError(err.Error() + "\n")
+		}
+		return 1
+	}
+	writer.Info("\n%d specifications resolved successfully\n", len(specs))
+	return 0
+}
+
+func (executor *specExecutor) dryRun() []error {
+	executor.writer.SpecHeading(executor.specification.heading.value)
+	if executor.specification.dataTable.table.getRowCount() == 0 {
+		return executor.dryRunScenarios()
+	}
+	dryRunErrors := make([]error, 0)
+	for _, executor.currentTableRow = range executor.dataTableRows {
+		executor.writer.Text(fmt.Sprintf("\nData table row %d\n", executor.currentTableRow+1))
+		dryRunErrors = append(dryRunErrors, executor.dryRunScenarios()...)
+	}
+	return dryRunErrors
+}
+
+func (executor *specExecutor) dryRunScenarios() []error {
+	dryRunErrors := make([]error, 0)
+	for _, scenario := range executor.specification.scenarios {
+		if scenario.isTableDriven() {
+			dryRunErrors = append(dryRunErrors, executor.dryRunScenarioTableRows(scenario)...)
+		} else if err := executor.dryRunScenario(scenario); err != nil {
+			dryRunErrors = append(dryRunErrors, err)
+		}
+	}
+	return dryRunErrors
+}
+
+func (executor *specExecutor) dryRunScenarioTableRows(scenario *scenario) []error {
+	dryRunErrors := make([]error, 0)
+	for executor.scenarioTableRow = 0; executor.scenarioTableRow < scenario.dataTable.table.getRowCount(); executor.scenarioTableRow++ {
+		if err := executor.dryRunScenario(scenario); err != nil {
+			dryRunErrors = append(dryRunErrors, err)
+		}
+	}
+	executor.scenarioTableRow = 0
+	return dryRunErrors
+}
+
+// Parameter lookups panic when a parameter cannot be resolved, so that is turned into an error for the scenario.
+func (executor *specExecutor) dryRunScenario(scenario *scenario) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = &dryRunError{fileName: executor.specification.fileName, scenario: scenario.heading.value, message: fmt.Sprintf("%v", r)}
+		}
+	}()
+	executor.currentScenario = scenario
+	executor.writer.ScenarioHeading(scenario.resolveHeading(executor.dataTableLookup()))
+	contextItems := executor.getContextItemsForScenarioExecution(executor.specification)
+	scenarioItems := executor.resolveItems(scenario.items)
+	tearDownItems := executor.getTearDownItemsForScenarioExecution(executor.specification)
+	executor.printResolvedItems(contextItems)
+	executor.printResolvedItems(scenarioItems)
+	executor.printResolvedItems(tearDownItems)
+	return nil
+}
+
+func (executor *specExecutor) printResolvedItems(protoItems []*gauge_messages.ProtoItem) {
+	for _, protoItem := range protoItems {
+		if protoItem.GetItemType() == gauge_messages.ProtoItem_Concept {
+			protoConcept := protoItem.GetConcept()
+			executor.writer.ConceptStarting(protoConcept)
+			executor.printResolvedItems(protoConcept.GetSteps())
+			executor.writer.ConceptFinished(protoConcept)
+		} else if protoItem.GetItemType() == gauge_messages.ProtoItem_Step {
+			stepRequest := executor.createStepRequest(protoItem.GetStep())
+			executor.writer.Text(formatStep(createStepFromStepRequest(stepRequest)))
+		}
+	}
+}
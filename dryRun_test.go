@@ -0,0 +1,96 @@
+// Copyright 2015 ThoughtWorks, Inc.
+
+// This file is part of Gauge.
+
+// Gauge is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+
+// Gauge is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+
+// You should have received a copy of the GNU General Public License
+// along with Gauge.  If not, see <http://www.gnu.org/licenses/>.
+
+package main
+
+import (
+	. "gopkg.in/check.v1"
+)
+
+func (s *MySuite) TestDryRunResolvesAllDataTableRows(c *C) {
+	specText := SpecBuilder().specHeading("A spec heading").
+		tableHeader("id", "name").
+		tableRow("123", "foo").
+		tableRow("456", "bar").
+		scenarioHeading("First scenario").
+		step("create user <id> <name>").
+		String()
+	spec, result := new(specParser).parse(specText, new(conceptDictionary))
+	c.Assert(result.ok, Equals, true)
+
+	executor := newSpecExecutor(spec, nil, nil, newSimpleConsoleWriter(), []int{0, 1})
+	errs := executor.dryRun()
+
+	c.Assert(len(errs), Equals, 0)
+	c.Assert(executor.currentTableRow, Equals, 1)
+}
+
+func (s *MySuite) TestDryRunReportsUnresolvedParameters(c *C) {
+	specText := SpecBuilder().specHeading("A spec heading").
+		scenarioHeading("First scenario").
+		step("create user \"foo\"").
+		String()
+	spec, result := new(specParser).parse(specText, new(conceptDictionary))
+	c.Assert(result.ok, Equals, true)
+	spec.fileName = "foo.spec"
+	spec.scenarios[0].steps[0].args[0] = &stepArg{argType: dynamic, value: "id"}
+
+	executor := newSpecExecutor(spec, nil, nil, newSimpleConsoleWriter(), []int{0})
+	errs := executor.dryRun()
+
+	c.Assert(len(errs), Equals, 1)
+	c.Assert(errs[0].Error(), Equals, "foo.spec : First scenario : Accessing an invalid parameter (id)")
+}
+
+func (s *MySuite) TestDryRunReportsUnresolvedParametersOfTearDownSteps(c *C) {
+	specText := SpecBuilder().specHeading("A spec heading").
+		scenarioHeading("First scenario").
+		step("a step").
+		String() + "___\n* teardown step \"foo\"\n"
+	spec, result := new(specParser).parse(specText, new(conceptDictionary))
+	c.Assert(result.ok, Equals, true)
+	c.Assert(len(spec.tearDownSteps), Equals, 1)
+	spec.fileName = "foo.spec"
+	spec.tearDownSteps[0].args[0] = &stepArg{argType: dynamic, value: "id"}
+
+	executor := newSpecExecutor(spec, nil, nil, newSimpleConsoleWriter(), []int{0})
+	errs := executor.dryRun()
+
+	c.Assert(len(errs), Equals, 1)
+	c.Assert(errs[0].Error(), Equals, "foo.spec : First scenario : Accessing an invalid parameter (id)")
+}
+
+func (s *MySuite) TestDryRunResolvesAllRowsOfScenarioDataTables(c *C) {
+	specText := SpecBuilder().specHeading("A spec heading").
+		scenarioHeading("First scenario").
+		tableHeader("id", "name").
+		tableRow("123", "foo").
+		tableRow("456", "bar").
+		step("create user <id> <name>").
+		scenarioHeading("Second scenario").
+		step("a step").
+		String()
+	spec, result := new(specParser).parse(specText, new(conceptDictionary))
+	c.Assert(result.ok, Equals, true)
+	c.Assert(spec.scenarios[0].isTableDriven(), Equals, true)
+
+	executor := newSpecExecutor(spec, nil, nil, newSimpleConsoleWriter(), []int{0})
+	errs := executor.dryRun()
+
+	c.Assert(len(errs), Equals, 0)
+	c.Assert(executor.currentScenario, Equals, spec.scenarios[1])
+}
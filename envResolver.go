@@ -0,0 +1,110 @@
+// Copyright 2015 ThoughtWorks, Inc.
+
+// This file is part of Gauge.
+
+// Gauge is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+
+// Gauge is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+
+// You should have received a copy of the GNU General Public License
+// along with Gauge.  If not, see <http://www.gnu.org/licenses/>.
+
+package main
+
+import (
+	"errors"
+	"fmt"
+	"github.com/getgauge/gauge/config"
+	"github.com/getgauge/gauge/gauge_messages"
+	"github.com/golang/protobuf/proto"
+	"os"
+	"regexp"
+	"strings"
+)
+
+const envParamPrefix = "env"
+
+var envVariablePattern = regexp.MustCompile(`\$\{([^}]*)\}`)
+
+// <env:NAME> is resolved only during execution, till then it is kept as ${NAME}
+func resolveEnvParam(name string) (*stepArg, error) {
+	if strings.TrimSpace(name) == "" {
+		return nil, errors.New("Environment variable name not specified")
+	}
+	return &stepArg{value: fmt.Sprintf("${%s}", strings.TrimSpace(name)), argType: specialString}, nil
+}
+
+// Replaces every ${NAME} in the value with the value of the environment variable NAME
+func interpolateEnvVariables(value string) (string, error) {
+	var err error
+	interpolated := envVariablePattern.ReplaceAllStringFunc(value, func(match string) string {
+		name := strings.TrimSpace(envVariablePattern.FindStringSubmatch(match)[1])
+		envValue := os.Getenv(name)
+		if envValue == "" && !isEnvVariableSet(name) && err == nil {
+			err = errors.New(fmt.Sprintf("Environment variable '%s' is not set", name))
+		}
+		return envValue
+	})
+	if err != nil {
+		return "", err
+	}
+	return interpolated, nil
+}
+
+// os.Getenv does not tell an empty variable from one which is not set
+func isEnvVariableSet(name string) bool {
+	for _, envVariable := range os.Environ() {
+		if strings.HasPrefix(envVariable, name+"=") {
+			return true
+		}
+	}
+	return false
+}
+
+// Resolves the <env:NAME> params of the step. The other step parameters and table cells are interpolated only
+// when interpolate_env_variables is set. Contents of files and text blocks are not interpolated.
+func resolveEnvVariables(fragments []*gauge_messages.Fragment) error {
+	interpolateAll := config.InterpolateEnvVariables()
+	for _, parameter := range getParameters(fragments) {
+		switch parameter.GetParameterType() {
+		case gauge_messages.Parameter_Special_String:
+			if !strings.HasPrefix(parameter.GetName(), envParamPrefix+":") {
+				continue
+			}
+			value, err := interpolateEnvVariables(parameter.GetValue())
+			if err != nil {
+				return err
+			}
+			parameter.Value = proto.String(value)
+		case gauge_messages.Parameter_Static, gauge_messages.Parameter_Dynamic:
+			if !interpolateAll {
+				continue
+			}
+			value, err := interpolateEnvVariables(parameter.GetValue())
+			if err != nil {
+				return err
+			}
+			parameter.Value = proto.String(value)
+		case gauge_messages.Parameter_Table:
+			if !interpolateAll {
+				continue
+			}
+			for _, row := range parameter.GetTable().GetRows() {
+				for i, cell := range row.GetCells() {
+					value, err := interpolateEnvVariables(cell)
+					if err != nil {
+						return err
+					}
+					row.Cells[i] = value
+				}
+			}
+		}
+	}
+	return nil
+}
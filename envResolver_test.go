@@ -0,0 +1,131 @@
+// Copyright 2015 ThoughtWorks, Inc.
+
+// This file is part of Gauge.
+
+// Gauge is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+
+// Gauge is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+
+// You should have received a copy of the GNU General Public License
+// along with Gauge.  If not, see <http://www.gnu.org/licenses/>.
+
+package main
+
+import (
+	"github.com/getgauge/gauge/gauge_messages"
+	"github.com/golang/protobuf/proto"
+	. "gopkg.in/check.v1"
+	"os"
+)
+
+func (s *MySuite) TestInterpolateEnvVariables(c *C) {
+	os.Setenv("GAUGE_TEST_HOST", "localhost")
+	os.Setenv("GAUGE_TEST_PORT", "8080")
+	defer os.Unsetenv("GAUGE_TEST_HOST")
+	defer os.Unsetenv("GAUGE_TEST_PORT")
+
+	value, err := interpolateEnvVariables("http://${GAUGE_TEST_HOST}:${ GAUGE_TEST_PORT }/login")
+
+	c.Assert(err, IsNil)
+	c.Assert(value, Equals, "http://localhost:8080/login")
+}
+
+func (s *MySuite) TestInterpolateMissingEnvVariable(c *C) {
+	_, err := interpolateEnvVariables("http://${GAUGE_TEST_MISSING_HOST}/login")
+
+	c.Assert(err.Error(), Equals, "Environment variable 'GAUGE_TEST_MISSING_HOST' is not set")
+}
+
+func (s *MySuite) TestParsingEnvParam(c *C) {
+	specText := SpecBuilder().specHeading("Spec Heading").scenarioHeading("Scenario Heading").step("login to <env:BASE_URL>").String()
+
+	spec, result := new(specParser).parse(specText, new(conceptDictionary))
+
+	c.Assert(result.ok, Equals, true)
+	arg := spec.scenarios[0].steps[0].args[0]
+	c.Assert(arg.argType, Equals, specialString)
+	c.Assert(arg.name, Equals, "env:BASE_URL")
+	c.Assert(arg.value, Equals, "${BASE_URL}")
+}
+
+func (s *MySuite) TestInterpolateEnvVariableSetToEmptyValue(c *C) {
+	os.Setenv("GAUGE_TEST_EMPTY_PATH", "")
+	defer os.Unsetenv("GAUGE_TEST_EMPTY_PATH")
+
+	value, err := interpolateEnvVariables("http://localhost${GAUGE_TEST_EMPTY_PATH}/login")
+
+	c.Assert(err, IsNil)
+	c.Assert(value, Equals, "http://localhost/login")
+}
+
+func (s *MySuite) TestResolveEnvVariablesInStepParameters(c *C) {
+	os.Setenv("GAUGE_TEST_USER", "admin")
+	os.Setenv("interpolate_env_variables", "true")
+	defer os.Unsetenv("GAUGE_TEST_USER")
+	defer os.Unsetenv("interpolate_env_variables")
+	table := &gauge_messages.ProtoTable{Headers: &gauge_messages.ProtoTableRow{Cells: []string{"user"}},
+		Rows: []*gauge_messages.ProtoTableRow{&gauge_messages.ProtoTableRow{Cells: []string{"${GAUGE_TEST_USER}"}}}}
+	fragments := []*gauge_messages.Fragment{
+		parameterFragment(&gauge_messages.Parameter{ParameterType: gauge_messages.Parameter_Static.Enum(), Value: proto.String("${GAUGE_TEST_USER}")}),
+		parameterFragment(&gauge_messages.Parameter{ParameterType: gauge_messages.Parameter_Dynamic.Enum(), Value: proto.String("user ${GAUGE_TEST_USER}")}),
+		parameterFragment(&gauge_messages.Parameter{ParameterType: gauge_messages.Parameter_Special_String.Enum(), Name: proto.String("env:GAUGE_TEST_USER"), Value: proto.String("${GAUGE_TEST_USER}")}),
+		parameterFragment(&gauge_messages.Parameter{ParameterType: gauge_messages.Parameter_Special_String.Enum(), Name: proto.String("file:script.sh"), Value: proto.String("echo ${HOME}")}),
+		parameterFragment(&gauge_messages.Parameter{ParameterType: gauge_messages.Parameter_Table.Enum(), Table: table}),
+	}
+
+	err := resolveEnvVariables(fragments)
+
+	c.Assert(err, IsNil)
+	c.Assert(fragments[0].GetParameter().GetValue(), Equals, "admin")
+	c.Assert(fragments[1].GetParameter().GetValue(), Equals, "user admin")
+	c.Assert(fragments[2].GetParameter().GetValue(), Equals, "admin")
+	c.Assert(fragments[3].GetParameter().GetValue(), Equals, "echo ${HOME}")
+	c.Assert(fragments[4].GetParameter().GetTable().GetRows()[0].GetCells()[0], Equals, "admin")
+}
+
+func (s *MySuite) TestOnlyEnvParamsAreResolvedUnlessInterpolationIsEnabled(c *C) {
+	os.Setenv("GAUGE_TEST_USER", "admin")
+	defer os.Unsetenv("GAUGE_TEST_USER")
+	table := &gauge_messages.ProtoTable{Headers: &gauge_messages.ProtoTableRow{Cells: []string{"template"}},
+		Rows: []*gauge_messages.ProtoTableRow{&gauge_messages.ProtoTableRow{Cells: []string{"${name}"}}}}
+	fragments := []*gauge_messages.Fragment{
+		parameterFragment(&gauge_messages.Parameter{ParameterType: gauge_messages.Parameter_Static.Enum(), Value: proto.String("Hello ${name}")}),
+		parameterFragment(&gauge_messages.Parameter{ParameterType: gauge_messages.Parameter_Special_String.Enum(), Name: proto.String("env:GAUGE_TEST_USER"), Value: proto.String("${GAUGE_TEST_USER}")}),
+		parameterFragment(&gauge_messages.Parameter{ParameterType: gauge_messages.Parameter_Table.Enum(), Table: table}),
+	}
+
+	err := resolveEnvVariables(fragments)
+
+	c.Assert(err, IsNil)
+	c.Assert(fragments[0].GetParameter().GetValue(), Equals, "Hello ${name}")
+	c.Assert(fragments[1].GetParameter().GetValue(), Equals, "admin")
+	c.Assert(fragments[2].GetParameter().GetTable().GetRows()[0].GetCells()[0], Equals, "${name}")
+}
+
+func (s *MySuite) TestStepWithMissingEnvVariableFailsWithoutExecution(c *C) {
+	specText := SpecBuilder().specHeading("A spec heading").
+		scenarioHeading("First scenario").
+		step("login to <env:GAUGE_TEST_MISSING_URL>").
+		String()
+	spec, _ := new(specParser).parse(specText, new(conceptDictionary))
+	executor := newSpecExecutor(spec, nil, nil, newSimpleConsoleWriter(), []int{0})
+	executor.currentExecutionInfo = &gauge_messages.ExecutionInfo{CurrentSpec: &gauge_messages.SpecInfo{IsFailed: proto.Bool(false)},
+		CurrentScenario: &gauge_messages.ScenarioInfo{IsFailed: proto.Bool(false)}}
+	protoStep := executor.resolveToProtoStepItem(spec.scenarios[0].steps[0]).GetStep()
+
+	failed := executor.executeStep(protoStep)
+
+	c.Assert(failed, Equals, true)
+	c.Assert(protoStep.GetStepExecutionResult().GetExecutionResult().GetErrorMessage(), Equals, "Environment variable 'GAUGE_TEST_MISSING_URL' is not set")
+	c.Assert(executor.currentExecutionInfo.GetCurrentStep().GetIsFailed(), Equals, true)
+}
+
+func parameterFragment(parameter *gauge_messages.Parameter) *gauge_messages.Fragment {
+	return &gauge_messages.Fragment{FragmentType: gauge_messages.Fragment_Parameter.Enum(), Parameter: parameter}
+}
@@ -18,6 +18,7 @@
 package main
 
 import (
+	"fmt"
 	"github.com/getgauge/gauge/config"
 	"github.com/getgauge/gauge/env"
 	"github.com/getgauge/gauge/gauge_messages"
@@ -28,11 +29,13 @@ import (
 type simpleExecution struct {
 	manifest             *manifest
 	runner               *testRunner
-	specifications       []*specification
+	specQueue            *specQueue
 	pluginHandler        *pluginHandler
 	currentExecutionInfo *gauge_messages.ExecutionInfo
 	suiteResult          *suiteResult
 	writer               executionLogger
+	failureThreshold     *failureThreshold
+	specParts            specParts
 }
 
 type execution interface {
@@ -45,22 +48,29 @@ type executionInfo struct {
 }
 
 func newExecution(manifest *manifest, specifications []*specification, runner *testRunner, pluginHandler *pluginHandler, info *parallelInfo, writer executionLogger) execution {
+	threshold := newFailureThreshold(getMaxFailures())
 	if info.inParallel {
-		return &parallelSpecExecution{manifest: manifest, specifications: specifications, runner: runner, pluginHandler: pluginHandler, numberOfExecutionStreams: info.numberOfStreams, writer: writer}
+		return &parallelSpecExecution{manifest: manifest, specifications: specifications, runner: runner, pluginHandler: pluginHandler, numberOfExecutionStreams: info.numberOfStreams, writer: writer, failureThreshold: threshold}
 	}
-	return &simpleExecution{manifest: manifest, specifications: specifications, runner: runner, pluginHandler: pluginHandler, writer: writer}
+	return &simpleExecution{manifest: manifest, specQueue: newSpecQueue(specifications), runner: runner, pluginHandler: pluginHandler, writer: writer, failureThreshold: threshold}
 }
 
-func (e *simpleExecution) startExecution() *(gauge_messages.ProtoExecutionResult) {
-	initSuiteDataStoreMessage := &gauge_messages.Message{MessageType: gauge_messages.Message_SuiteDataStoreInit.Enum(),
+func newSuiteDataStoreInitMessage() *gauge_messages.Message {
+	return &gauge_messages.Message{MessageType: gauge_messages.Message_SuiteDataStoreInit.Enum(),
 		SuiteDataStoreInitRequest: &gauge_messages.SuiteDataStoreInitRequest{}}
-	initResult := executeAndGetStatus(e.runner, initSuiteDataStoreMessage, e.writer)
+}
+
+func newExecutionStartingMessage() *gauge_messages.Message {
+	return &gauge_messages.Message{MessageType: gauge_messages.Message_ExecutionStarting.Enum(),
+		ExecutionStartingRequest: &gauge_messages.ExecutionStartingRequest{}}
+}
+
+func (e *simpleExecution) startExecution() *(gauge_messages.ProtoExecutionResult) {
+	initResult := e.executeMessage(newSuiteDataStoreInitMessage())
 	if initResult.GetFailed() {
 		e.writer.Warning("Suite data store didn't get initialized")
 	}
-	message := &gauge_messages.Message{MessageType: gauge_messages.Message_ExecutionStarting.Enum(),
-		ExecutionStartingRequest: &gauge_messages.ExecutionStartingRequest{}}
-	return e.executeHook(message)
+	return e.executeHook(newExecutionStartingMessage())
 }
 
 func (e *simpleExecution) endExecution() *(gauge_messages.ProtoExecutionResult) {
@@ -71,11 +81,20 @@ func (e *simpleExecution) endExecution() *(gauge_messages.ProtoExecutionResult)
 
 func (e *simpleExecution) executeHook(message *gauge_messages.Message) *(gauge_messages.ProtoExecutionResult) {
 	e.pluginHandler.notifyPlugins(message)
-	executionResult := executeAndGetStatus(e.runner, message, e.writer)
+	executionResult := e.executeMessage(message)
 	e.addExecTime(executionResult.GetExecutionTime())
 	return executionResult
 }
 
+// Suite hooks are run outside of any spec, so only the configured step timeout applies to them.
+func (e *simpleExecution) executeMessage(message *gauge_messages.Message) *(gauge_messages.ProtoExecutionResult) {
+	timeout := config.StepTimeout()
+	if timeout <= 0 {
+		return executeAndGetStatus(e.runner, message, e.writer)
+	}
+	return executeWithTimeout(e.runner, message, timeout, fmt.Sprintf("Hook timed out after %s", timeout), e.writer, newSuiteDataStoreInitMessage())
+}
+
 func (e *simpleExecution) addExecTime(execTime int64) {
 	e.suiteResult.executionTime += execTime
 }
@@ -109,10 +128,22 @@ func (exe *simpleExecution) start() *suiteResult {
 		addPreHook(exe.suiteResult, beforeSuiteHookExecResult)
 		exe.suiteResult.setFailure()
 	} else {
-		for _, specificationToExecute := range exe.specifications {
-			executor := newSpecExecutor(specificationToExecute, exe.runner, exe.pluginHandler, exe.writer, getDataTableRows(specificationToExecute.dataTable.table.getRowCount()))
+		for specificationToExecute := exe.specQueue.next(); specificationToExecute != nil; specificationToExecute = exe.specQueue.next() {
+			if exe.failureThreshold.isReached() {
+				remainingSpecs := append([]*specification{specificationToExecute}, exe.specQueue.drain()...)
+				exe.suiteResult.unhandledErrors = append(exe.suiteResult.unhandledErrors, exe.failureThreshold.skippedSpecsError(remainingSpecs))
+				break
+			}
+			if exe.runner.restartError != nil {
+				remainingSpecs := &specCollection{specs: append([]*specification{specificationToExecute}, exe.specQueue.drain()...)}
+				exe.suiteResult.unhandledErrors = append(exe.suiteResult.unhandledErrors, streamExecError{specsSkipped: remainingSpecs.specNames(), message: runnerLostResult(exe.runner).GetErrorMessage()})
+				break
+			}
+			executor := newSpecExecutor(specificationToExecute, exe.runner, exe.pluginHandler, exe.writer, exe.specParts.getDataTableRows(specificationToExecute))
 			protoSpecResult := executor.execute()
+			exe.specParts.addResult(specificationToExecute, protoSpecResult)
 			exe.suiteResult.addSpecResult(protoSpecResult)
+			exe.failureThreshold.addSpecResult(protoSpecResult)
 		}
 	}
 	afterSuiteHookExecResult := exe.endExecution()
@@ -136,7 +167,7 @@ func (e *simpleExecution) stopAllPlugins() {
 	}
 }
 
-func newSpecExecutor(specToExecute *specification, runner *testRunner, pluginHandler *pluginHandler, writer executionLogger, tableRows indexRange) *specExecutor {
+func newSpecExecutor(specToExecute *specification, runner *testRunner, pluginHandler *pluginHandler, writer executionLogger, tableRows []int) *specExecutor {
 	specExecutor := new(specExecutor)
 	specExecutor.initialize(specToExecute, runner, pluginHandler, writer, tableRows)
 	return specExecutor
@@ -0,0 +1,69 @@
+// Copyright 2015 ThoughtWorks, Inc.
+
+// This file is part of Gauge.
+
+// Gauge is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+
+// Gauge is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+
+// You should have received a copy of the GNU General Public License
+// along with Gauge.  If not, see <http://www.gnu.org/licenses/>.
+
+package main
+
+import (
+	"fmt"
+	"sync"
+)
+
+// failureThreshold counts the failed specs across all the execution streams, so that no new specs
+// are scheduled once the maximum number of failures is reached. A maximum of zero means no limit.
+// Specs split into parts by --parallel-scenarios are counted once, however many of their parts fail.
+type failureThreshold struct {
+	maxFailures int
+	failedSpecs map[string]bool
+	mutex       sync.Mutex
+}
+
+func newFailureThreshold(maxFailures int) *failureThreshold {
+	return &failureThreshold{maxFailures: maxFailures, failedSpecs: make(map[string]bool)}
+}
+
+func getMaxFailures() int {
+	if *maxFailures > 0 {
+		return *maxFailures
+	}
+	if *failFast {
+		return 1
+	}
+	return 0
+}
+
+func (threshold *failureThreshold) addSpecResult(result *specResult) {
+	if threshold == nil || !result.isFailed {
+		return
+	}
+	threshold.mutex.Lock()
+	defer threshold.mutex.Unlock()
+	threshold.failedSpecs[result.protoSpec.GetFileName()] = true
+}
+
+func (threshold *failureThreshold) isReached() bool {
+	if threshold == nil || threshold.maxFailures <= 0 {
+		return false
+	}
+	threshold.mutex.Lock()
+	defer threshold.mutex.Unlock()
+	return len(threshold.failedSpecs) >= threshold.maxFailures
+}
+
+func (threshold *failureThreshold) skippedSpecsError(specs []*specification) error {
+	collection := &specCollection{specs: specs}
+	return streamExecError{specsSkipped: collection.specNames(), message: fmt.Sprintf("Execution stopped after %d failed specification(s)", threshold.maxFailures)}
+}
@@ -0,0 +1,84 @@
+// Copyright 2015 ThoughtWorks, Inc.
+
+// This file is part of Gauge.
+
+// Gauge is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+
+// Gauge is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+
+// You should have received a copy of the GNU General Public License
+// along with Gauge.  If not, see <http://www.gnu.org/licenses/>.
+
+package main
+
+import (
+	"github.com/getgauge/gauge/gauge_messages"
+	"github.com/golang/protobuf/proto"
+	. "gopkg.in/check.v1"
+)
+
+func specResultOf(fileName string, isFailed bool) *specResult {
+	return &specResult{protoSpec: &gauge_messages.ProtoSpec{FileName: proto.String(fileName)}, isFailed: isFailed}
+}
+
+func (s *MySuite) TestFailureThresholdIsReachedAfterMaxFailedSpecs(c *C) {
+	threshold := newFailureThreshold(2)
+
+	threshold.addSpecResult(specResultOf("spec1.spec", true))
+	threshold.addSpecResult(specResultOf("spec2.spec", false))
+	c.Assert(threshold.isReached(), Equals, false)
+
+	threshold.addSpecResult(specResultOf("spec3.spec", true))
+	c.Assert(threshold.isReached(), Equals, true)
+}
+
+func (s *MySuite) TestFailureThresholdCountsFailedPartsOfASpecOnce(c *C) {
+	threshold := newFailureThreshold(2)
+
+	threshold.addSpecResult(specResultOf("spec1.spec", true))
+	threshold.addSpecResult(specResultOf("spec1.spec", true))
+	c.Assert(threshold.isReached(), Equals, false)
+
+	threshold.addSpecResult(specResultOf("spec2.spec", true))
+	c.Assert(threshold.isReached(), Equals, true)
+}
+
+func (s *MySuite) TestFailureThresholdWithoutLimitIsNeverReached(c *C) {
+	threshold := newFailureThreshold(0)
+	threshold.addSpecResult(specResultOf("spec1.spec", true))
+
+	c.Assert(threshold.isReached(), Equals, false)
+
+	var noThreshold *failureThreshold
+	noThreshold.addSpecResult(specResultOf("spec1.spec", true))
+	c.Assert(noThreshold.isReached(), Equals, false)
+}
+
+func (s *MySuite) TestFailureThresholdReportsUnexecutedSpecsAsSkipped(c *C) {
+	threshold := newFailureThreshold(1)
+
+	err := threshold.skippedSpecsError([]*specification{&specification{fileName: "spec1.spec"}, &specification{fileName: "spec2.spec"}})
+
+	c.Assert(err.(streamExecError).numberOfSpecsSkipped(), Equals, 2)
+	c.Assert(err.Error(), Equals, "The following specifications could not be executed:\n"+
+		"spec1.spec\n"+
+		"spec2.spec\n"+
+		"Reason : Execution stopped after 1 failed specification(s).")
+}
+
+func (s *MySuite) TestFailureThresholdReportsSkippedPartsOfASpecOnce(c *C) {
+	threshold := newFailureThreshold(1)
+	spec := &specification{fileName: "spec1.spec", scenarios: []*scenario{&scenario{heading: &heading{value: "first"}}, &scenario{heading: &heading{value: "second"}}}}
+	parts, _ := splitSpecs([]*specification{spec, &specification{fileName: "spec2.spec"}})
+
+	err := threshold.skippedSpecsError(parts)
+
+	c.Assert(len(parts), Equals, 3)
+	c.Assert(err.(streamExecError).specsSkipped, DeepEquals, []string{"spec1.spec", "spec2.spec"})
+}
@@ -23,6 +23,10 @@ type formatter struct {
 	buffer bytes.Buffer
 }
 
+func (formatter *formatter) specMetadata(metadata *metadata) {
+	formatter.buffer.WriteString(formatMetadata(metadata))
+}
+
 func (formatter *formatter) specHeading(specHeading *heading) {
 	formatter.buffer.WriteString(formatHeading(specHeading.value, "="))
 }
@@ -31,6 +35,10 @@ func (formatter *formatter) specTags(tags *tags) {
 	formatter.buffer.WriteString(formatTags(tags))
 }
 
+func (formatter *formatter) specSkip(skip *skip) {
+	formatter.buffer.WriteString(formatSkip(skip))
+}
+
 func (formatter *formatter) dataTable(table *table) {
 	formatter.buffer.WriteString(formatTable(table))
 }
@@ -43,6 +51,14 @@ func (formatter *formatter) contextStep(step *step) {
 	formatter.step(step)
 }
 
+func (formatter *formatter) tearDown(tearDown *tearDown) {
+	formatter.buffer.WriteString(formatTearDown(tearDown))
+}
+
+func (formatter *formatter) tearDownStep(step *step) {
+	formatter.step(step)
+}
+
 func (formatter *formatter) scenario(scenario *scenario) {
 }
 
@@ -54,6 +70,10 @@ func (formatter *formatter) scenarioTags(scenarioTags *tags) {
 	formatter.specTags(scenarioTags)
 }
 
+func (formatter *formatter) scenarioSkip(scenarioSkip *skip) {
+	formatter.specSkip(scenarioSkip)
+}
+
 func (formatter *formatter) step(step *step) {
 	formatter.buffer.WriteString(formatStep(step))
 }
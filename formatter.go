@@ -67,6 +67,8 @@ func formatStep(step *step) string {
 			formattedArg = fmt.Sprintf("\n%s", formattedTable)
 		} else if argument.argType == dynamic {
 			formattedArg = fmt.Sprintf("<%s>", getUnescapedString(argument.value))
+		} else if isTextBlock(argument) {
+			formattedArg = fmt.Sprintf("\n%s", formatTextBlock(argument.name, argument.value))
 		} else if argument.argType == specialString || argument.argType == specialTable {
 			formattedArg = fmt.Sprintf("<%s>", getUnescapedString(argument.name))
 		} else {
@@ -106,30 +108,55 @@ func formatHeading(heading, headingChar string) string {
 }
 
 func formatTable(table *table) string {
+	if table.isVertical {
+		return formatVerticalTable(table)
+	}
 	columnToWidthMap := make(map[int]int)
 	for i, header := range table.headers {
 		//table.get(header) returns a list of cells in that particular column
 		cells := table.get(header)
 		columnToWidthMap[i] = findLongestCellWidth(cells, len(header))
 	}
+	return formatTableRows(table.headers, table.getRows(), columnToWidthMap)
+}
 
+//vertical tables are written back as the author wrote them, with the orientation marker and a record in each column
+func formatVerticalTable(table *table) string {
+	rows := table.getVerticalRows()
+	columnToWidthMap := make(map[int]int)
+	for _, row := range rows {
+		for i, cell := range row {
+			if len(cell) > columnToWidthMap[i] {
+				columnToWidthMap[i] = len(cell)
+			}
+		}
+	}
+	orientation := table.orientation
+	if orientation == "" {
+		orientation = verticalTableOrientations[0]
+	}
+	marker := fmt.Sprintf("%s%s %s\n", getRepeatedChars(" ", TABLE_LEFT_SPACING), tableOrientationMarker, orientation)
+	return marker + formatTableRows(rows[0], rows[1:], columnToWidthMap)
+}
+
+func formatTableRows(headers []string, rows [][]string, columnToWidthMap map[int]int) string {
 	var tableStringBuffer bytes.Buffer
 	tableStringBuffer.WriteString(fmt.Sprintf("%s|", getRepeatedChars(" ", TABLE_LEFT_SPACING)))
-	for i, header := range table.headers {
+	for i, header := range headers {
 		width := columnToWidthMap[i]
 		tableStringBuffer.WriteString(fmt.Sprintf("%s|", addPaddingToCell(header, width)))
 	}
 
 	tableStringBuffer.WriteString("\n")
 	tableStringBuffer.WriteString(fmt.Sprintf("%s|", getRepeatedChars(" ", TABLE_LEFT_SPACING)))
-	for i, _ := range table.headers {
+	for i, _ := range headers {
 		width := columnToWidthMap[i]
 		cell := getRepeatedChars("-", width)
 		tableStringBuffer.WriteString(fmt.Sprintf("%s|", addPaddingToCell(cell, width)))
 	}
 
 	tableStringBuffer.WriteString("\n")
-	for _, row := range table.getRows() {
+	for _, row := range rows {
 		tableStringBuffer.WriteString(fmt.Sprintf("%s|", getRepeatedChars(" ", TABLE_LEFT_SPACING)))
 		for i, cell := range row {
 			width := columnToWidthMap[i]
@@ -164,6 +191,35 @@ func formatComment(comment *comment) string {
 	return fmt.Sprintf("%s\n", comment.value)
 }
 
+func isTextBlock(arg *stepArg) bool {
+	return arg.argType == specialString && strings.HasPrefix(arg.name, textBlockFence)
+}
+
+func formatTextBlock(openingFence, text string) string {
+	if len(text) == 0 {
+		return fmt.Sprintf("%s\n%s\n", openingFence, textBlockFence)
+	}
+	return fmt.Sprintf("%s\n%s\n%s\n", openingFence, text, textBlockFence)
+}
+
+func formatTearDown(tearDown *tearDown) string {
+	return fmt.Sprintf("%s\n", tearDown.value)
+}
+
+func formatMetadata(metadata *metadata) string {
+	var b bytes.Buffer
+	b.WriteString(metadataSeparator + "\n")
+	for _, entry := range metadata.entries {
+		b.WriteString(fmt.Sprintf("%s: %s\n", entry.key, entry.value))
+	}
+	b.WriteString(metadataSeparator + "\n")
+	return string(b.Bytes())
+}
+
+func formatSkip(skip *skip) string {
+	return fmt.Sprintf("%s %s\n", skipMarker, skip.reason)
+}
+
 func formatTags(tags *tags) string {
 	if tags == nil || len(tags.values) == 0 {
 		return ""
@@ -19,6 +19,7 @@ package main
 
 import (
 	. "gopkg.in/check.v1"
+	"strings"
 )
 
 func (s *MySuite) TestFormatSpecification(c *C) {
@@ -64,6 +65,24 @@ func (s *MySuite) TestFormatConcepts(c *C) {
 `)
 }
 
+func (s *MySuite) TestFormatConceptsKeepsTextBlocks(c *C) {
+	conceptText := `# my concept
+* first step
+` + "```json" + `
+{"a": 1}
+` + "```" + `
+* second step
+`
+	concepts, result := new(conceptParser).parse(conceptText)
+	c.Assert(result.ok, Equals, true)
+	dictionary := new(conceptDictionary)
+	dictionary.add(concepts, "file.cpt")
+
+	formatted := formatConcepts(dictionary)
+
+	c.Assert(formatted["file.cpt"], Equals, strings.Replace(conceptText, "* first step\n", "* first step \n", 1))
+}
+
 func (s *MySuite) TestFormatSpecificationWithTags(c *C) {
 	tokens := []*token{
 		&token{kind: specKind, value: "My Spec Heading", lineNo: 1},
@@ -94,6 +113,53 @@ tags: tag3, tag4
 
 }
 
+func (s *MySuite) TestFormatSpecificationWithMetadata(c *C) {
+	specText := `---
+owner:qa-team
+jira: GAUGE-123
+---
+# Spec Heading
+## Scenario Heading
+* Example step
+`
+
+	spec, _ := new(specParser).parse(specText, new(conceptDictionary))
+	formatted := formatSpecification(spec)
+	c.Assert(formatted, Equals,
+		`---
+owner: qa-team
+jira: GAUGE-123
+---
+Spec Heading
+============
+Scenario Heading
+----------------
+* Example step
+`)
+}
+
+func (s *MySuite) TestFormatSpecificationWithSkipMarkers(c *C) {
+	tokens := []*token{
+		&token{kind: specKind, value: "My Spec Heading", lineNo: 1},
+		&token{kind: skipKind, value: "not ready yet", lineNo: 2},
+		&token{kind: scenarioKind, value: "Scenario Heading", lineNo: 3},
+		&token{kind: skipKind, value: "known issue", lineNo: 4},
+		&token{kind: stepKind, value: "Example step", lineNo: 5, lineText: "Example step"},
+	}
+
+	spec, _ := new(specParser).createSpecification(tokens, new(conceptDictionary))
+	formatted := formatSpecification(spec)
+	c.Assert(formatted, Equals,
+		`My Spec Heading
+===============
+@skip: not ready yet
+Scenario Heading
+----------------
+@skip: known issue
+* Example step
+`)
+}
+
 func (s *MySuite) TestFormatStep(c *C) {
 	step := &step{value: "my step with {}, {}, {} and {}", args: []*stepArg{&stepArg{value: "static \"foo\"", argType: static},
 		&stepArg{value: "dynamic \"foo\"", argType: dynamic},
@@ -108,10 +174,11 @@ func (s *MySuite) TestFormattingWithTableAsAComment(c *C) {
 	tokens := []*token{
 		&token{kind: specKind, value: "My Spec Heading", lineNo: 1},
 		&token{kind: scenarioKind, value: "Scenario Heading", lineNo: 3},
+		&token{kind: stepKind, value: "Example step", lineNo: 4, lineText: "Example step"},
+		&token{kind: commentKind, value: "A comment", lineNo: 5},
 		&token{kind: tableHeader, args: []string{"id", "name"}, lineText: " |id|name|"},
 		&token{kind: tableRow, args: []string{"1", "foo"}, lineText: " |1|foo|"},
 		&token{kind: tableRow, args: []string{"2", "bar"}, lineText: "|2|bar|"},
-		&token{kind: stepKind, value: "Example step", lineNo: 5, lineText: "Example step"},
 	}
 
 	spec, _ := new(specParser).createSpecification(tokens, new(conceptDictionary))
@@ -121,10 +188,11 @@ func (s *MySuite) TestFormattingWithTableAsAComment(c *C) {
 ===============
 Scenario Heading
 ----------------
+* Example step
+A comment
  |id|name|
  |1|foo|
 |2|bar|
-* Example step
 `)
 }
 
@@ -161,3 +229,113 @@ Scenario Heading
      |2 |bar  |
 `)
 }
+
+func (s *MySuite) TestFormatSpecificationWithTearDownSteps(c *C) {
+	tokens := []*token{
+		&token{kind: specKind, value: "Spec Heading", lineNo: 1},
+		&token{kind: scenarioKind, value: "Scenario Heading", lineNo: 2},
+		&token{kind: stepKind, value: "Example step", lineNo: 3, lineText: "Example step"},
+		&token{kind: tearDownKind, value: "___", lineNo: 4, lineText: "___"},
+		&token{kind: stepKind, value: "Clean up", lineNo: 5, lineText: "Clean up"},
+	}
+
+	spec, _ := new(specParser).createSpecification(tokens, new(conceptDictionary))
+
+	formatted := formatSpecification(spec)
+
+	c.Assert(formatted, Equals,
+		`Spec Heading
+============
+Scenario Heading
+----------------
+* Example step
+___
+* Clean up
+`)
+}
+
+func (s *MySuite) TestFormatSpecificationWithTextBlock(c *C) {
+	specText := `Spec Heading
+============
+Scenario Heading
+----------------
+* Post payload
+` + "```json" + `
+{
+  "id": 1
+}
+` + "```" + `
+* Empty block
+` + "```" + `
+` + "```" + `
+`
+	spec, result := new(specParser).parse(specText, new(conceptDictionary))
+	c.Assert(result.ok, Equals, true)
+
+	// Like inline tables, the step text keeps the space before its text block parameter
+	expected := strings.Replace(strings.Replace(specText, "* Post payload\n", "* Post payload \n", 1), "* Empty block\n", "* Empty block \n", 1)
+	c.Assert(formatSpecification(spec), Equals, expected)
+}
+
+func (s *MySuite) TestFormatSpecificationKeepsOrientationOfVerticalTable(c *C) {
+	specText := `Spec Heading
+============
+Scenario Heading
+----------------
+* Create user 
+     orientation: vertical
+     |name |foo    |bar    |
+     |-----|-------|-------|
+     |email|foo@b.c|bar@b.c|
+`
+	spec, result := new(specParser).parse(specText, new(conceptDictionary))
+	c.Assert(result.ok, Equals, true)
+
+	c.Assert(formatSpecification(spec), Equals, specText)
+}
+
+func (s *MySuite) TestFormatSpecificationKeepsVerticalDataTables(c *C) {
+	specText := `Spec Heading
+============
+     orientation: transposed
+     |name |foo    |bar    |
+     |-----|-------|-------|
+     |email|foo@b.c|bar@b.c|
+Scenario Heading
+----------------
+* Create user <name> with <email>
+`
+	spec, result := new(specParser).parse(specText, new(conceptDictionary))
+	c.Assert(result.ok, Equals, true)
+	c.Assert(spec.dataTable.table.isVertical, Equals, true)
+
+	formatted := formatSpecification(spec)
+	c.Assert(formatted, Equals, specText)
+
+	reparsed, result := new(specParser).parse(formatted, new(conceptDictionary))
+	c.Assert(result.ok, Equals, true)
+	c.Assert(reparsed.dataTable.table.get("email")[1].value, Equals, "bar@b.c")
+}
+
+func (s *MySuite) TestFormatSpecificationKeepsVerticalScenarioDataTables(c *C) {
+	specText := `Spec Heading
+============
+Scenario Heading
+----------------
+     orientation: vertical
+     |id  |1  |2  |
+     |----|---|---|
+     |role|dev|ops|
+* Create user <id> with <role>
+`
+	spec, result := new(specParser).parse(specText, new(conceptDictionary))
+	c.Assert(result.ok, Equals, true)
+	c.Assert(spec.scenarios[0].isTableDriven(), Equals, true)
+
+	formatted := formatSpecification(spec)
+	c.Assert(formatted, Equals, specText)
+
+	reparsed, result := new(specParser).parse(formatted, new(conceptDictionary))
+	c.Assert(result.ok, Equals, true)
+	c.Assert(reparsed.scenarios[0].dataTable.table.get("role")[1].value, Equals, "ops")
+}
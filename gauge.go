@@ -65,14 +65,24 @@ var addPlugin = flag.String([]string{"-add-plugin"}, "", "Adds the specified non
 var pluginArgs = flag.String([]string{"-plugin-args"}, "", "Specified additional arguments to the plugin. This is used together with --add-plugin")
 var specFilesToFormat = flag.String([]string{"-format"}, "", "Formats the specified spec files")
 var executeTags = flag.String([]string{"-tags"}, "", "Executes the specs and scenarios tagged with given tags. Eg: gauge --tags tag1,tag2 specs")
-var tableRows = flag.String([]string{"-table-rows"}, "", "Executes the specs and scenarios only for the selected rows. Eg: gauge --table-rows \"1-3\" specs/hello.spec")
+var tableRows = flag.String([]string{"-table-rows"}, "", "Executes the specs and scenarios only for the selected rows. Eg: gauge --table-rows \"1,3,7-9\" specs/hello.spec. Rows of a single spec can be selected with specs/hello.spec:rows=1,3")
+var tableFilter = flag.String([]string{"-table-filter"}, "", "Executes the specs and scenarios only for the rows having the given column values. Eg: gauge --table-filter \"browser=chrome\" specs")
 var apiPort = flag.String([]string{"-api-port"}, "", "Specifies the api port to be used. Eg: gauge --daemonize --api-port 7777")
 var refactor = flag.String([]string{"-refactor"}, "", "Refactor steps")
 var parallel = flag.Bool([]string{"-parallel", "p"}, false, "Execute specs in parallel")
+var parallelScenarios = flag.Bool([]string{"-parallel-scenarios"}, false, "Spreads the scenarios and data table rows of each spec across the parallel streams. Used along with --parallel. Eg: gauge -p --parallel-scenarios specs")
 var numberOfExecutionStreams = flag.Int([]string{"n"}, numberOfCores(), "Specify number of parallel execution streams")
 var distribute = flag.Int([]string{"g", "-group"}, -1, "Specify which group of specification to execute based on -n flag")
+var specTimingsFile = flag.String([]string{"-timings"}, "", "Balances the groups of --group by the spec execution times in the given file instead of the number of specs. The times of complete runs are recorded in .gauge/timings.json. Eg: gauge -n 4 -g 1 --timings timings.json specs")
 var workingDir = flag.String([]string{"-dir"}, ".", "Set the working directory for the current command, accepts a path relative to current directory.")
 var doNotRandomize = flag.Bool([]string{"-sort", "s"}, false, "run specs in Alphabetical Order. Eg: gauge -s specs")
+var dryRun = flag.Bool([]string{"-dry-run"}, false, "Validates and resolves the specs without executing any steps. Eg: gauge --dry-run specs")
+var rerunFailed = flag.Bool([]string{"-failed"}, false, "Executes only the scenarios that failed in the previous run. Spec arguments limit the rerun to the failures under them. Eg: gauge --failed specs/login")
+var junitXml = flag.String([]string{"-junit-xml"}, "", "Writes the execution result as a JUnit XML report to the given file. Eg: gauge --junit-xml reports/junit.xml specs")
+var resultJson = flag.String([]string{"-result-json"}, "", "Writes the complete execution result as JSON to the given file. Eg: gauge --result-json reports/result.json specs")
+var maxRetries = flag.Int([]string{"-max-retries"}, 0, "Re-runs a failed scenario up to the given number of times. Eg: gauge --max-retries 2 specs")
+var failFast = flag.Bool([]string{"-fail-fast"}, false, "Stops executing further specs after the first failed specification. Eg: gauge --fail-fast specs")
+var maxFailures = flag.Int([]string{"-max-failures"}, 0, "Stops executing further specs once the given number of specifications have failed. Eg: gauge --max-failures 5 specs")
 
 func main() {
 	flag.Parse()
@@ -105,7 +115,7 @@ func main() {
 	} else if *refactor != "" && validGaugeProject {
 		refactorSteps(*refactor, newStepName())
 	} else {
-		if len(flag.Args()) == 0 {
+		if len(flag.Args()) == 0 && !*rerunFailed {
 			printUsage()
 		} else if validGaugeProject {
 			if *distribute != -1 {
@@ -232,12 +242,6 @@ func addPluginToProject(pluginName string) {
 
 func executeSpecs(inParallel bool) {
 	env.LoadEnv(*currentEnv, false)
-	conceptsDictionary, conceptParseResult := createConceptsDictionary(false)
-	handleParseResult(conceptParseResult)
-	specsToExecute, specsSkipped := getSpecsToExecute(conceptsDictionary)
-	if len(specsToExecute) == 0 {
-		printExecutionStatus(nil, 0)
-	}
 	parallelInfo := &parallelInfo{inParallel: inParallel, numberOfStreams: *numberOfExecutionStreams}
 	if !parallelInfo.isValid() {
 		os.Exit(1)
@@ -254,30 +258,79 @@ func executeSpecs(inParallel bool) {
 	if apiHandler.runner == nil {
 		handleCriticalError(errors.New("Failed to start a runner\n"))
 	}
+	// Runner and special param resolver plugins are started before parsing so that they can resolve the special params they registered
+	pluginHandler := startSpecialParamResolverPlugins(manifest)
 
-	validateSpecs(manifest, specsToExecute, apiHandler.runner, conceptsDictionary)
-	pluginHandler := startPlugins(manifest)
+	conceptsDictionary, conceptParseResults := createConceptsDictionary(false)
+	specsToExecute, specsSkipped, specParseResults := getSpecsToExecute(conceptsDictionary)
+	if !printParseResults(append(conceptParseResults, specParseResults...)...) {
+		killProcesses(apiHandler.runner, pluginHandler)
+		os.Exit(1)
+	}
+	if len(specsToExecute) == 0 {
+		killProcesses(apiHandler.runner, pluginHandler)
+		printExecutionStatus(nil, 0)
+	}
+
+	validateSpecs(manifest, specsToExecute, apiHandler.runner, pluginHandler, conceptsDictionary)
+	if *dryRun {
+		killProcesses(apiHandler.runner, pluginHandler)
+		os.Exit(dryRunSpecs(specsToExecute, getCurrentLogger()))
+	}
+	pluginHandler.startPlugins(manifest)
 	execution := newExecution(manifest, specsToExecute, apiHandler.runner, pluginHandler, parallelInfo, getCurrentLogger())
 	result := execution.start()
 	execution.finish()
+	saveFailedScenarios(result)
+	if isCompleteRun() {
+		saveSpecTimings(result)
+	}
+	if *junitXml != "" {
+		writeJUnitReport(*junitXml, convertToProtoSuiteResult(result))
+	}
+	if *resultJson != "" {
+		writeJSONResult(*resultJson, result, specsSkipped)
+	}
 	exitCode := printExecutionStatus(result, specsSkipped)
 	os.Exit(exitCode)
 }
 
+func killProcesses(runner *testRunner, pluginHandler *pluginHandler) {
+	runner.kill(getCurrentLogger())
+	pluginHandler.gracefullyKillPlugins()
+}
+
 func handleCriticalError(err error) {
 	getCurrentLogger().Critical(err.Error())
 	os.Exit(1)
 }
 
-func getDataTableRows(rowCount int) indexRange {
-	if *tableRows == "" {
-		return indexRange{start: 0, end: rowCount - 1}
-	}
-	indexes, err := getDataTableRowsRange(*tableRows, rowCount)
+func getDataTableRows(spec *specification) []int {
+	rows, err := selectDataTableRows(spec, *tableRows, *tableFilter)
 	if err != nil {
 		handleCriticalError(errors.New(fmt.Sprintf("Table rows validation failed. %s\n", err.Error())))
 	}
-	return indexes
+	return rows
+}
+
+// Rows selected in the spec argument take precedence over the rows selected for the whole run
+func selectDataTableRows(spec *specification, tableRows string, tableFilter string) ([]int, error) {
+	table := &spec.dataTable.table
+	rowCount := table.getRowCount()
+	if spec.dataTableRows != "" {
+		tableRows = spec.dataTableRows
+	}
+	rows := selectedRows(rowCount, func(int) bool { return true })
+	if tableRows != "" {
+		var err error
+		if rows, err = getDataTableRowIndexes(tableRows, rowCount); err != nil {
+			return nil, err
+		}
+	}
+	if tableFilter != "" && rowCount > 0 {
+		return filterDataTableRows(table, rows, tableFilter)
+	}
+	return rows, nil
 }
 
 func shuffleSpecs(allSpecs []*specification) []*specification {
@@ -290,25 +343,44 @@ func shuffleSpecs(allSpecs []*specification) []*specification {
 	return dest
 }
 
-func validateSpecs(manifest *manifest, specsToExecute []*specification, runner *testRunner, conceptDictionary *conceptDictionary) {
+func validateSpecs(manifest *manifest, specsToExecute []*specification, runner *testRunner, pluginHandler *pluginHandler, conceptDictionary *conceptDictionary) {
 	validator := newValidator(manifest, specsToExecute, runner, conceptDictionary)
 	validationErrors := validator.validate()
 	if len(validationErrors) > 0 {
 		printValidationFailures(validationErrors)
-		runner.kill(getCurrentLogger())
+		killProcesses(runner, pluginHandler)
 		os.Exit(1)
 	}
 }
 
-func getSpecsToExecute(conceptsDictionary *conceptDictionary) ([]*specification, int) {
-	specsToExecute := specsFromArgs(conceptsDictionary)
+func getSpecsToExecute(conceptsDictionary *conceptDictionary) ([]*specification, int, []*parseResult) {
+	var specsToExecute []*specification
+	var parseResults []*parseResult
+	if *rerunFailed {
+		specsToExecute, parseResults = specsFromFailures(flag.Args(), conceptsDictionary)
+	} else {
+		specsToExecute, parseResults = specsFromArgs(conceptsDictionary)
+	}
 	totalSpecs := specsToExecute
 	specsToExecute = applyFilters(specsToExecute, specsFilters())
-	return sortSpecsList(specsToExecute), len(totalSpecs) - len(specsToExecute)
+	return sortSpecsList(specsToExecute), len(totalSpecs) - len(specsToExecute), parseResults
+}
+
+// A run is complete when every selected spec is run with all its scenarios and data table rows
+func isCompleteRun() bool {
+	if *rerunFailed || *executeTags != "" || *tableRows != "" || *tableFilter != "" {
+		return false
+	}
+	for _, arg := range flag.Args() {
+		if isIndexedSpec(arg) || isTableRowsSpec(arg) {
+			return false
+		}
+	}
+	return true
 }
 
 func specsFilters() []specsFilter {
-	return []specsFilter{&tagsFilter{*executeTags}, &specsGroupFilter{*distribute, *numberOfExecutionStreams}, &specRandomizer{*doNotRandomize}}
+	return []specsFilter{&tagsFilter{*executeTags}, &tableRowsFilter{*tableRows, *tableFilter}, &specsGroupFilter{*distribute, *numberOfExecutionStreams, *specTimingsFile}, &specRandomizer{*doNotRandomize}}
 }
 
 func applyFilters(specsToExecute []*specification, filters []specsFilter) []*specification {
@@ -422,10 +494,18 @@ func createProjectTemplate(language string) error {
 }
 
 func handleParseResult(results ...*parseResult) {
+	if !printParseResults(results...) {
+		os.Exit(1)
+	}
+}
+
+// Logs the errors and warnings of the parse results and returns false if any of them failed
+func printParseResults(results ...*parseResult) bool {
+	parseSucceeded := true
 	for _, result := range results {
 		if !result.ok {
 			logger.Log.Critical(result.Error())
-			os.Exit(1)
+			parseSucceeded = false
 		}
 		if result.warnings != nil {
 			for _, warning := range result.warnings {
@@ -433,6 +513,7 @@ func handleParseResult(results ...*parseResult) {
 			}
 		}
 	}
+	return parseSucceeded
 }
 
 func startRunnerAndMakeConnection(manifest *manifest, writer executionLogger) (*testRunner, error) {
@@ -469,10 +550,12 @@ func printExecutionStatus(suiteResult *suiteResult, specsSkipped int) int {
 		logger.Log.Info("No specifications found.")
 		os.Exit(0)
 	}
-	noOfSpecificationsExecuted := len(suiteResult.specResults)
+	noOfSpecificationsExecuted := len(suiteResult.specResults) - suiteResult.specsSkippedCount
 	noOfScenariosExecuted := 0
+	noOfScenariosSkipped := 0
 	noOfSpecificationsFailed := suiteResult.specsFailedCount
 	noOfScenariosFailed := 0
+	noOfScenariosFlaky := 0
 	exitCode := 0
 	if suiteResult.isFailed {
 		logger.Log.Info("\nThe following failures occured:\n")
@@ -482,17 +565,27 @@ func printExecutionStatus(suiteResult *suiteResult, specsSkipped int) int {
 	printHookError(suiteResult.preSuite)
 
 	for _, specResult := range suiteResult.specResults {
-		noOfScenariosExecuted += specResult.scenarioCount
+		noOfScenariosExecuted += specResult.scenarioCount - specResult.scenarioSkippedCount
+		noOfScenariosSkipped += specResult.scenarioSkippedCount
 		noOfScenariosFailed += specResult.scenarioFailedCount
+		noOfScenariosFlaky += specResult.flakyScenarioCount
 		printSpecFailure(specResult)
 	}
 
 	printHookError(suiteResult.postSuite)
+	printSkipped(suiteResult)
 
+	specsSkipped += suiteResult.specsSkippedCount
 	for _, unhandledErr := range suiteResult.unhandledErrors {
 		specsSkipped += (unhandledErr).(streamExecError).numberOfSpecsSkipped()
 	}
 	logger.Log.Info("%d scenarios executed, %d failed\n", noOfScenariosExecuted, noOfScenariosFailed)
+	if noOfScenariosFlaky > 0 {
+		logger.Log.Info("%d scenarios passed only after retrying\n", noOfScenariosFlaky)
+	}
+	if noOfScenariosSkipped > 0 {
+		logger.Log.Info("%d scenarios skipped\n", noOfScenariosSkipped)
+	}
 	logger.Log.Info("%d specifications executed, %d failed\n", noOfSpecificationsExecuted, noOfSpecificationsFailed)
 	logger.Log.Info("%d specifications skipped\n", specsSkipped)
 	logger.Log.Info("%s\n", time.Millisecond*time.Duration(suiteResult.executionTime))
@@ -502,6 +595,47 @@ func printExecutionStatus(suiteResult *suiteResult, specsSkipped int) int {
 	return exitCode
 }
 
+// Lists the specs and scenarios skipped with a skip marker, along with the reasons
+func printSkipped(suiteResult *suiteResult) {
+	skipped := make([]string, 0)
+	for _, specResult := range suiteResult.specResults {
+		if specResult.isSkipped {
+			skipped = append(skipped, fmt.Sprintf("%s : %s (skipped: %s)", specResult.protoSpec.GetFileName(), specResult.protoSpec.GetSpecHeading(), specResult.skipReason))
+			continue
+		}
+		for _, protoScenario := range skippedScenarios(specResult.protoSpec) {
+			skipped = append(skipped, fmt.Sprintf("%s : %s (skipped: %s)", specResult.protoSpec.GetFileName(), protoScenario.GetScenarioHeading(), protoScenario.GetSkipReason()))
+		}
+	}
+	if len(skipped) == 0 {
+		return
+	}
+	logger.Log.Info("\nThe following were skipped:\n")
+	for _, line := range skipped {
+		logger.Log.Info(line)
+	}
+	logger.Log.Info("")
+}
+
+// A table driven scenario is listed once, even though each of its rows is skipped
+func skippedScenarios(protoSpec *gauge_messages.ProtoSpec) []*gauge_messages.ProtoScenario {
+	scenarios := make([]*gauge_messages.ProtoScenario, 0)
+	for _, item := range protoSpec.GetItems() {
+		switch item.GetItemType() {
+		case gauge_messages.ProtoItem_Scenario:
+			if item.GetScenario().GetSkipped() {
+				scenarios = append(scenarios, item.GetScenario())
+			}
+		case gauge_messages.ProtoItem_TableDrivenScenario:
+			rows := item.GetTableDrivenScenario().GetScenarios()
+			if len(rows) > 0 && rows[0].GetSkipped() {
+				scenarios = append(scenarios, rows[0])
+			}
+		}
+	}
+	return scenarios
+}
+
 func printHookError(hook *(gauge_messages.ProtoHookFailure)) {
 	if hook != nil {
 		console := getCurrentLogger()
@@ -587,21 +721,24 @@ func getSpecFiles(specSource string) []string {
 	return specFiles
 }
 
-func specsFromArgs(conceptDictionary *conceptDictionary) []*specification {
+func specsFromArgs(conceptDictionary *conceptDictionary) ([]*specification, []*parseResult) {
 	allSpecs := make([]*specification, 0)
 	specs := make([]*specification, 0)
+	allParseResults := make([]*parseResult, 0)
 	var specParseResults []*parseResult
 	for _, arg := range flag.Args() {
 		specSource := arg
 		if isIndexedSpec(specSource) {
 			specs, specParseResults = getSpecWithScenarioIndex(specSource, conceptDictionary)
+		} else if isTableRowsSpec(specSource) {
+			specs, specParseResults = getSpecWithTableRows(specSource, conceptDictionary)
 		} else {
 			specs, specParseResults = findSpecs(specSource, conceptDictionary)
 		}
-		handleParseResult(specParseResults...)
+		allParseResults = append(allParseResults, specParseResults...)
 		allSpecs = append(allSpecs, specs...)
 	}
-	return allSpecs
+	return allSpecs, allParseResults
 }
 
 func getSpecWithScenarioIndex(specSource string, conceptDictionary *conceptDictionary) ([]*specification, []*parseResult) {
@@ -610,6 +747,15 @@ func getSpecWithScenarioIndex(specSource string, conceptDictionary *conceptDicti
 	return filterSpecsItems(parsedSpecs, newScenarioIndexFilterToRetain(indexToFilter)), parseResult
 }
 
+func getSpecWithTableRows(specSource string, conceptDictionary *conceptDictionary) ([]*specification, []*parseResult) {
+	specName, rows := getTableRowsSpec(specSource)
+	parsedSpecs, parseResult := findSpecs(specName, conceptDictionary)
+	for _, spec := range parsedSpecs {
+		spec.dataTableRows = rows
+	}
+	return parsedSpecs, parseResult
+}
+
 func findSpecs(specSource string, conceptDictionary *conceptDictionary) ([]*specification, []*parseResult) {
 	specFiles := getSpecFiles(specSource)
 
@@ -643,7 +789,7 @@ func parseSpec(specFile string, conceptDictionary *conceptDictionary, specChanne
 		parseResultChan <- &parseResult{error: &parseError{message: err.Error()}, ok: false, fileName: specFile}
 		return
 	}
-	spec, parseResult := new(specParser).parse(specFileContent, conceptDictionary)
+	spec, parseResult := (&specParser{fileName: specFile}).parse(specFileContent, conceptDictionary)
 	parseResult.fileName = specFile
 	if !parseResult.ok {
 		specChannel <- nil
@@ -281,7 +281,9 @@ type ConceptInfo struct {
 	// / The absolute path to the file that contains the Concept
 	Filepath *string `protobuf:"bytes,2,req,name=filepath" json:"filepath,omitempty"`
 	// / The line number in the file where the concept is defined.
-	LineNumber       *int32 `protobuf:"varint,3,req,name=lineNumber" json:"lineNumber,omitempty"`
+	LineNumber *int32 `protobuf:"varint,3,req,name=lineNumber" json:"lineNumber,omitempty"`
+	// / Span of the concept heading in the file.
+	Span             *Span  `protobuf:"bytes,4,opt,name=span" json:"span,omitempty"`
 	XXX_unrecognized []byte `json:"-"`
 }
 
@@ -310,6 +312,13 @@ func (m *ConceptInfo) GetLineNumber() int32 {
 	return 0
 }
 
+func (m *ConceptInfo) GetSpan() *Span {
+	if m != nil {
+		return m.Span
+	}
+	return nil
+}
+
 // / Request to get a Step Value.
 type GetStepValueRequest struct {
 	// / The text of the Step.
@@ -38,6 +38,10 @@ It has these top-level messages:
 	StepNameRequest
 	StepNameResponse
 	UnsupportedMessageResponse
+	SpecialParamPrefixesRequest
+	SpecialParamPrefixesResponse
+	ResolveSpecialParamRequest
+	ResolveSpecialParamResponse
 	Message
 */
 package gauge_messages
@@ -52,30 +56,34 @@ var _ = math.Inf
 type Message_MessageType int32
 
 const (
-	Message_ExecutionStarting          Message_MessageType = 0
-	Message_SpecExecutionStarting      Message_MessageType = 1
-	Message_SpecExecutionEnding        Message_MessageType = 2
-	Message_ScenarioExecutionStarting  Message_MessageType = 3
-	Message_ScenarioExecutionEnding    Message_MessageType = 4
-	Message_StepExecutionStarting      Message_MessageType = 5
-	Message_StepExecutionEnding        Message_MessageType = 6
-	Message_ExecuteStep                Message_MessageType = 7
-	Message_ExecutionEnding            Message_MessageType = 8
-	Message_StepValidateRequest        Message_MessageType = 9
-	Message_StepValidateResponse       Message_MessageType = 10
-	Message_ExecutionStatusResponse    Message_MessageType = 11
-	Message_StepNamesRequest           Message_MessageType = 12
-	Message_StepNamesResponse          Message_MessageType = 13
-	Message_KillProcessRequest         Message_MessageType = 14
-	Message_SuiteExecutionResult       Message_MessageType = 15
-	Message_ScenarioDataStoreInit      Message_MessageType = 16
-	Message_SpecDataStoreInit          Message_MessageType = 17
-	Message_SuiteDataStoreInit         Message_MessageType = 18
-	Message_StepNameRequest            Message_MessageType = 19
-	Message_StepNameResponse           Message_MessageType = 20
-	Message_RefactorRequest            Message_MessageType = 21
-	Message_RefactorResponse           Message_MessageType = 22
-	Message_UnsupportedMessageResponse Message_MessageType = 23
+	Message_ExecutionStarting            Message_MessageType = 0
+	Message_SpecExecutionStarting        Message_MessageType = 1
+	Message_SpecExecutionEnding          Message_MessageType = 2
+	Message_ScenarioExecutionStarting    Message_MessageType = 3
+	Message_ScenarioExecutionEnding      Message_MessageType = 4
+	Message_StepExecutionStarting        Message_MessageType = 5
+	Message_StepExecutionEnding          Message_MessageType = 6
+	Message_ExecuteStep                  Message_MessageType = 7
+	Message_ExecutionEnding              Message_MessageType = 8
+	Message_StepValidateRequest          Message_MessageType = 9
+	Message_StepValidateResponse         Message_MessageType = 10
+	Message_ExecutionStatusResponse      Message_MessageType = 11
+	Message_StepNamesRequest             Message_MessageType = 12
+	Message_StepNamesResponse            Message_MessageType = 13
+	Message_KillProcessRequest           Message_MessageType = 14
+	Message_SuiteExecutionResult         Message_MessageType = 15
+	Message_ScenarioDataStoreInit        Message_MessageType = 16
+	Message_SpecDataStoreInit            Message_MessageType = 17
+	Message_SuiteDataStoreInit           Message_MessageType = 18
+	Message_StepNameRequest              Message_MessageType = 19
+	Message_StepNameResponse             Message_MessageType = 20
+	Message_RefactorRequest              Message_MessageType = 21
+	Message_RefactorResponse             Message_MessageType = 22
+	Message_UnsupportedMessageResponse   Message_MessageType = 23
+	Message_SpecialParamPrefixesRequest  Message_MessageType = 24
+	Message_SpecialParamPrefixesResponse Message_MessageType = 25
+	Message_ResolveSpecialParamRequest   Message_MessageType = 26
+	Message_ResolveSpecialParamResponse  Message_MessageType = 27
 )
 
 var Message_MessageType_name = map[int32]string{
@@ -103,32 +111,40 @@ var Message_MessageType_name = map[int32]string{
 	21: "RefactorRequest",
 	22: "RefactorResponse",
 	23: "UnsupportedMessageResponse",
+	24: "SpecialParamPrefixesRequest",
+	25: "SpecialParamPrefixesResponse",
+	26: "ResolveSpecialParamRequest",
+	27: "ResolveSpecialParamResponse",
 }
 var Message_MessageType_value = map[string]int32{
-	"ExecutionStarting":          0,
-	"SpecExecutionStarting":      1,
-	"SpecExecutionEnding":        2,
-	"ScenarioExecutionStarting":  3,
-	"ScenarioExecutionEnding":    4,
-	"StepExecutionStarting":      5,
-	"StepExecutionEnding":        6,
-	"ExecuteStep":                7,
-	"ExecutionEnding":            8,
-	"StepValidateRequest":        9,
-	"StepValidateResponse":       10,
-	"ExecutionStatusResponse":    11,
-	"StepNamesRequest":           12,
-	"StepNamesResponse":          13,
-	"KillProcessRequest":         14,
-	"SuiteExecutionResult":       15,
-	"ScenarioDataStoreInit":      16,
-	"SpecDataStoreInit":          17,
-	"SuiteDataStoreInit":         18,
-	"StepNameRequest":            19,
-	"StepNameResponse":           20,
-	"RefactorRequest":            21,
-	"RefactorResponse":           22,
-	"UnsupportedMessageResponse": 23,
+	"ExecutionStarting":            0,
+	"SpecExecutionStarting":        1,
+	"SpecExecutionEnding":          2,
+	"ScenarioExecutionStarting":    3,
+	"ScenarioExecutionEnding":      4,
+	"StepExecutionStarting":        5,
+	"StepExecutionEnding":          6,
+	"ExecuteStep":                  7,
+	"ExecutionEnding":              8,
+	"StepValidateRequest":          9,
+	"StepValidateResponse":         10,
+	"ExecutionStatusResponse":      11,
+	"StepNamesRequest":             12,
+	"StepNamesResponse":            13,
+	"KillProcessRequest":           14,
+	"SuiteExecutionResult":         15,
+	"ScenarioDataStoreInit":        16,
+	"SpecDataStoreInit":            17,
+	"SuiteDataStoreInit":           18,
+	"StepNameRequest":              19,
+	"StepNameResponse":             20,
+	"RefactorRequest":              21,
+	"RefactorResponse":             22,
+	"UnsupportedMessageResponse":   23,
+	"SpecialParamPrefixesRequest":  24,
+	"SpecialParamPrefixesResponse": 25,
+	"ResolveSpecialParamRequest":   26,
+	"ResolveSpecialParamResponse":  27,
 }
 
 func (x Message_MessageType) Enum() *Message_MessageType {
@@ -366,8 +382,10 @@ type SpecInfo struct {
 	// / Flag to indicate if the current Spec execution failed.
 	IsFailed *bool `protobuf:"varint,3,req,name=isFailed" json:"isFailed,omitempty"`
 	// / Tags relevant to the current Spec execution.
-	Tags             []string `protobuf:"bytes,4,rep,name=tags" json:"tags,omitempty"`
-	XXX_unrecognized []byte   `json:"-"`
+	Tags []string `protobuf:"bytes,4,rep,name=tags" json:"tags,omitempty"`
+	// / Metadata defined at the top of the current Spec, in the order in which it is defined.
+	Metadata         []*MetadataEntry `protobuf:"bytes,5,rep,name=metadata" json:"metadata,omitempty"`
+	XXX_unrecognized []byte           `json:"-"`
 }
 
 func (m *SpecInfo) Reset()         { *m = SpecInfo{} }
@@ -402,6 +420,13 @@ func (m *SpecInfo) GetTags() []string {
 	return nil
 }
 
+func (m *SpecInfo) GetMetadata() []*MetadataEntry {
+	if m != nil {
+		return m.Metadata
+	}
+	return nil
+}
+
 // / Contains details of the Scenario execution.
 type ScenarioInfo struct {
 	// / Name of the current Scenario being executed.
@@ -812,6 +837,87 @@ func (m *UnsupportedMessageResponse) GetMessage() string {
 	return ""
 }
 
+// / Request sent to the runner or a plugin to find out the special param prefixes it can resolve.
+type SpecialParamPrefixesRequest struct {
+	XXX_unrecognized []byte `json:"-"`
+}
+
+func (m *SpecialParamPrefixesRequest) Reset()         { *m = SpecialParamPrefixesRequest{} }
+func (m *SpecialParamPrefixesRequest) String() string { return proto.CompactTextString(m) }
+func (*SpecialParamPrefixesRequest) ProtoMessage()    {}
+
+// / Response to SpecialParamPrefixesRequest.
+type SpecialParamPrefixesResponse struct {
+	// / Prefixes of the special params, e.g. "json" for <json:data.json>
+	Prefixes         []string `protobuf:"bytes,1,rep,name=prefixes" json:"prefixes,omitempty"`
+	XXX_unrecognized []byte   `json:"-"`
+}
+
+func (m *SpecialParamPrefixesResponse) Reset()         { *m = SpecialParamPrefixesResponse{} }
+func (m *SpecialParamPrefixesResponse) String() string { return proto.CompactTextString(m) }
+func (*SpecialParamPrefixesResponse) ProtoMessage()    {}
+
+func (m *SpecialParamPrefixesResponse) GetPrefixes() []string {
+	if m != nil {
+		return m.Prefixes
+	}
+	return nil
+}
+
+// / Request sent to resolve a special param of a registered prefix.
+type ResolveSpecialParamRequest struct {
+	// / Prefix of the special param, the part before ':'
+	Prefix *string `protobuf:"bytes,1,req,name=prefix" json:"prefix,omitempty"`
+	// / Value of the special param, the part after ':'
+	Value            *string `protobuf:"bytes,2,req,name=value" json:"value,omitempty"`
+	XXX_unrecognized []byte  `json:"-"`
+}
+
+func (m *ResolveSpecialParamRequest) Reset()         { *m = ResolveSpecialParamRequest{} }
+func (m *ResolveSpecialParamRequest) String() string { return proto.CompactTextString(m) }
+func (*ResolveSpecialParamRequest) ProtoMessage()    {}
+
+func (m *ResolveSpecialParamRequest) GetPrefix() string {
+	if m != nil && m.Prefix != nil {
+		return *m.Prefix
+	}
+	return ""
+}
+
+func (m *ResolveSpecialParamRequest) GetValue() string {
+	if m != nil && m.Value != nil {
+		return *m.Value
+	}
+	return ""
+}
+
+// / Response to ResolveSpecialParamRequest.
+type ResolveSpecialParamResponse struct {
+	// / The resolved param. Special_String params carry the value, Special_Table params carry the table.
+	Parameter *Parameter `protobuf:"bytes,1,opt,name=parameter" json:"parameter,omitempty"`
+	// / Set when the special param could not be resolved.
+	ErrorMessage     *string `protobuf:"bytes,2,opt,name=errorMessage" json:"errorMessage,omitempty"`
+	XXX_unrecognized []byte  `json:"-"`
+}
+
+func (m *ResolveSpecialParamResponse) Reset()         { *m = ResolveSpecialParamResponse{} }
+func (m *ResolveSpecialParamResponse) String() string { return proto.CompactTextString(m) }
+func (*ResolveSpecialParamResponse) ProtoMessage()    {}
+
+func (m *ResolveSpecialParamResponse) GetParameter() *Parameter {
+	if m != nil {
+		return m.Parameter
+	}
+	return nil
+}
+
+func (m *ResolveSpecialParamResponse) GetErrorMessage() string {
+	if m != nil && m.ErrorMessage != nil {
+		return *m.ErrorMessage
+	}
+	return ""
+}
+
 // / This is the message which gets transferred all the time
 // / with proper message type set
 // / One of the Request/Response fields will have value, depending on the MessageType set.
@@ -868,7 +974,15 @@ type Message struct {
 	RefactorResponse *RefactorResponse `protobuf:"bytes,25,opt,name=refactorResponse" json:"refactorResponse,omitempty"`
 	// / [UnsupportedMessageResponse](#gauge.messages.UnsupportedMessageResponse)
 	UnsupportedMessageResponse *UnsupportedMessageResponse `protobuf:"bytes,26,opt,name=unsupportedMessageResponse" json:"unsupportedMessageResponse,omitempty"`
-	XXX_unrecognized           []byte                      `json:"-"`
+	// / [SpecialParamPrefixesRequest](#gauge.messages.SpecialParamPrefixesRequest)
+	SpecialParamPrefixesRequest *SpecialParamPrefixesRequest `protobuf:"bytes,27,opt,name=specialParamPrefixesRequest" json:"specialParamPrefixesRequest,omitempty"`
+	// / [SpecialParamPrefixesResponse](#gauge.messages.SpecialParamPrefixesResponse)
+	SpecialParamPrefixesResponse *SpecialParamPrefixesResponse `protobuf:"bytes,28,opt,name=specialParamPrefixesResponse" json:"specialParamPrefixesResponse,omitempty"`
+	// / [ResolveSpecialParamRequest](#gauge.messages.ResolveSpecialParamRequest)
+	ResolveSpecialParamRequest *ResolveSpecialParamRequest `protobuf:"bytes,29,opt,name=resolveSpecialParamRequest" json:"resolveSpecialParamRequest,omitempty"`
+	// / [ResolveSpecialParamResponse](#gauge.messages.ResolveSpecialParamResponse)
+	ResolveSpecialParamResponse *ResolveSpecialParamResponse `protobuf:"bytes,30,opt,name=resolveSpecialParamResponse" json:"resolveSpecialParamResponse,omitempty"`
+	XXX_unrecognized            []byte                       `json:"-"`
 }
 
 func (m *Message) Reset()         { *m = Message{} }
@@ -1057,6 +1171,34 @@ func (m *Message) GetUnsupportedMessageResponse() *UnsupportedMessageResponse {
 	return nil
 }
 
+func (m *Message) GetSpecialParamPrefixesRequest() *SpecialParamPrefixesRequest {
+	if m != nil {
+		return m.SpecialParamPrefixesRequest
+	}
+	return nil
+}
+
+func (m *Message) GetSpecialParamPrefixesResponse() *SpecialParamPrefixesResponse {
+	if m != nil {
+		return m.SpecialParamPrefixesResponse
+	}
+	return nil
+}
+
+func (m *Message) GetResolveSpecialParamRequest() *ResolveSpecialParamRequest {
+	if m != nil {
+		return m.ResolveSpecialParamRequest
+	}
+	return nil
+}
+
+func (m *Message) GetResolveSpecialParamResponse() *ResolveSpecialParamResponse {
+	if m != nil {
+		return m.ResolveSpecialParamResponse
+	}
+	return nil
+}
+
 func init() {
 	proto.RegisterEnum("gauge.messages.Message_MessageType", Message_MessageType_name, Message_MessageType_value)
 }
@@ -27,6 +27,8 @@ It has these top-level messages:
 	ProtoSuiteResult
 	ProtoSpecResult
 	ProtoStepValue
+	Span
+	MetadataEntry
 */
 package gauge_messages
 
@@ -179,8 +181,12 @@ type ProtoSpec struct {
 	// / Contains the filename for that holds this specification.
 	FileName *string `protobuf:"bytes,6,req,name=fileName" json:"fileName,omitempty"`
 	// / Contains a list of tags that are defined at the specification level. Scenario tags are not present here.
-	Tags             []string `protobuf:"bytes,7,rep,name=tags" json:"tags,omitempty"`
-	XXX_unrecognized []byte   `json:"-"`
+	Tags []string `protobuf:"bytes,7,rep,name=tags" json:"tags,omitempty"`
+	// / Span of the Spec heading in the file.
+	HeadingSpan *Span `protobuf:"bytes,8,opt,name=headingSpan" json:"headingSpan,omitempty"`
+	// / Metadata defined at the top of the Spec file, in the order in which it is defined.
+	Metadata         []*MetadataEntry `protobuf:"bytes,9,rep,name=metadata" json:"metadata,omitempty"`
+	XXX_unrecognized []byte           `json:"-"`
 }
 
 func (m *ProtoSpec) Reset()         { *m = ProtoSpec{} }
@@ -236,6 +242,20 @@ func (m *ProtoSpec) GetTags() []string {
 	return nil
 }
 
+func (m *ProtoSpec) GetHeadingSpan() *Span {
+	if m != nil {
+		return m.HeadingSpan
+	}
+	return nil
+}
+
+func (m *ProtoSpec) GetMetadata() []*MetadataEntry {
+	if m != nil {
+		return m.Metadata
+	}
+	return nil
+}
+
 // / Container for all valid Items under a Specification.
 type ProtoItem struct {
 	// / Itemtype of the current ProtoItem
@@ -253,8 +273,10 @@ type ProtoItem struct {
 	// / Holds the Table definition. Valid only if ItemType = Table
 	Table *ProtoTable `protobuf:"bytes,7,opt,name=table" json:"table,omitempty"`
 	// / Holds the Tags definition. Valid only if ItemType = Tags
-	Tags             *ProtoTags `protobuf:"bytes,8,opt,name=tags" json:"tags,omitempty"`
-	XXX_unrecognized []byte     `json:"-"`
+	Tags *ProtoTags `protobuf:"bytes,8,opt,name=tags" json:"tags,omitempty"`
+	// / Span of the item in the file. For a Scenario, this is the span of its heading.
+	Span             *Span  `protobuf:"bytes,9,opt,name=span" json:"span,omitempty"`
+	XXX_unrecognized []byte `json:"-"`
 }
 
 func (m *ProtoItem) Reset()         { *m = ProtoItem{} }
@@ -317,6 +339,13 @@ func (m *ProtoItem) GetTags() *ProtoTags {
 	return nil
 }
 
+func (m *ProtoItem) GetSpan() *Span {
+	if m != nil {
+		return m.Span
+	}
+	return nil
+}
+
 // / A proto object representing a Scenario
 type ProtoScenario struct {
 	// / Heading of the given Scenario
@@ -334,8 +363,18 @@ type ProtoScenario struct {
 	// / Contains a list of tags that are defined at the specification level. Scenario tags are not present here.
 	Tags []string `protobuf:"bytes,7,rep,name=tags" json:"tags,omitempty"`
 	// / Holds the time taken for executing this scenario.
-	ExecutionTime    *int64 `protobuf:"varint,8,opt,name=executionTime" json:"executionTime,omitempty"`
-	XXX_unrecognized []byte `json:"-"`
+	ExecutionTime *int64 `protobuf:"varint,8,opt,name=executionTime" json:"executionTime,omitempty"`
+	// / Holds the results of the earlier failed attempts, when the scenario was retried.
+	PreviousAttempts []*ProtoScenario `protobuf:"bytes,9,rep,name=previousAttempts" json:"previousAttempts,omitempty"`
+	// / Flag to indicate if the Scenario passed only after being retried.
+	Flaky *bool `protobuf:"varint,10,opt,name=flaky" json:"flaky,omitempty"`
+	// / Collection of TearDown steps. The TearDown steps are executed after every run, even if the scenario fails.
+	TearDownSteps []*ProtoItem `protobuf:"bytes,11,rep,name=tearDownSteps" json:"tearDownSteps,omitempty"`
+	// / Flag to indicate if the Scenario was skipped instead of being executed.
+	Skipped *bool `protobuf:"varint,12,opt,name=skipped" json:"skipped,omitempty"`
+	// / The reason for skipping the Scenario. Valid only if Skipped = true
+	SkipReason       *string `protobuf:"bytes,13,opt,name=skipReason" json:"skipReason,omitempty"`
+	XXX_unrecognized []byte  `json:"-"`
 }
 
 func (m *ProtoScenario) Reset()         { *m = ProtoScenario{} }
@@ -398,6 +437,41 @@ func (m *ProtoScenario) GetExecutionTime() int64 {
 	return 0
 }
 
+func (m *ProtoScenario) GetPreviousAttempts() []*ProtoScenario {
+	if m != nil {
+		return m.PreviousAttempts
+	}
+	return nil
+}
+
+func (m *ProtoScenario) GetFlaky() bool {
+	if m != nil && m.Flaky != nil {
+		return *m.Flaky
+	}
+	return false
+}
+
+func (m *ProtoScenario) GetTearDownSteps() []*ProtoItem {
+	if m != nil {
+		return m.TearDownSteps
+	}
+	return nil
+}
+
+func (m *ProtoScenario) GetSkipped() bool {
+	if m != nil && m.Skipped != nil {
+		return *m.Skipped
+	}
+	return false
+}
+
+func (m *ProtoScenario) GetSkipReason() string {
+	if m != nil && m.SkipReason != nil {
+		return *m.SkipReason
+	}
+	return ""
+}
+
 // / A proto object representing a TableDrivenScenario
 type ProtoTableDrivenScenario struct {
 	// / Holds the Underlying scenario that is executed for every row in the table.
@@ -426,7 +500,9 @@ type ProtoStep struct {
 	Fragments []*Fragment `protobuf:"bytes,3,rep,name=fragments" json:"fragments,omitempty"`
 	// / Holds the result from the execution.
 	StepExecutionResult *ProtoStepExecutionResult `protobuf:"bytes,4,opt,name=stepExecutionResult" json:"stepExecutionResult,omitempty"`
-	XXX_unrecognized    []byte                    `json:"-"`
+	// / Span of the Step in the file.
+	Span             *Span  `protobuf:"bytes,5,opt,name=span" json:"span,omitempty"`
+	XXX_unrecognized []byte `json:"-"`
 }
 
 func (m *ProtoStep) Reset()         { *m = ProtoStep{} }
@@ -461,6 +537,13 @@ func (m *ProtoStep) GetStepExecutionResult() *ProtoStepExecutionResult {
 	return nil
 }
 
+func (m *ProtoStep) GetSpan() *Span {
+	if m != nil {
+		return m.Span
+	}
+	return nil
+}
+
 // / Concept is a type of step, that can have multiple Steps.
 // / But from a caller's perspective, it is still used as any other Step
 // / A proto object representing a Concept
@@ -502,8 +585,10 @@ func (m *ProtoConcept) GetConceptExecutionResult() *ProtoStepExecutionResult {
 // / A proto object representing Tags
 type ProtoTags struct {
 	// / A collection of Tags
-	Tags             []string `protobuf:"bytes,1,rep,name=tags" json:"tags,omitempty"`
-	XXX_unrecognized []byte   `json:"-"`
+	Tags []string `protobuf:"bytes,1,rep,name=tags" json:"tags,omitempty"`
+	// / Spans of the Tags in the file, in the same order as the Tags.
+	Spans            []*Span `protobuf:"bytes,2,rep,name=spans" json:"spans,omitempty"`
+	XXX_unrecognized []byte  `json:"-"`
 }
 
 func (m *ProtoTags) Reset()         { *m = ProtoTags{} }
@@ -517,6 +602,13 @@ func (m *ProtoTags) GetTags() []string {
 	return nil
 }
 
+func (m *ProtoTags) GetSpans() []*Span {
+	if m != nil {
+		return m.Spans
+	}
+	return nil
+}
+
 // / A proto object representing Fragment.
 // / Fragments, put together make up A Step
 type Fragment struct {
@@ -563,8 +655,10 @@ type Parameter struct {
 	// / Holds the name of the parameter, used as Key to lookup the value.
 	Name *string `protobuf:"bytes,3,opt,name=name" json:"name,omitempty"`
 	// / Holds the table value, if parameterType=Table or Special_Table
-	Table            *ProtoTable `protobuf:"bytes,4,opt,name=table" json:"table,omitempty"`
-	XXX_unrecognized []byte      `json:"-"`
+	Table *ProtoTable `protobuf:"bytes,4,opt,name=table" json:"table,omitempty"`
+	// / Span of the Parameter in the file.
+	Span             *Span  `protobuf:"bytes,5,opt,name=span" json:"span,omitempty"`
+	XXX_unrecognized []byte `json:"-"`
 }
 
 func (m *Parameter) Reset()         { *m = Parameter{} }
@@ -599,6 +693,13 @@ func (m *Parameter) GetTable() *ProtoTable {
 	return nil
 }
 
+func (m *Parameter) GetSpan() *Span {
+	if m != nil {
+		return m.Span
+	}
+	return nil
+}
+
 // / A proto object representing Comment.
 type ProtoComment struct {
 	// / Text representing the Comment.
@@ -647,8 +748,10 @@ func (m *ProtoTable) GetRows() []*ProtoTableRow {
 // / A proto object representing Table.
 type ProtoTableRow struct {
 	// / Represents the cells of a given table
-	Cells            []string `protobuf:"bytes,1,rep,name=cells" json:"cells,omitempty"`
-	XXX_unrecognized []byte   `json:"-"`
+	Cells []string `protobuf:"bytes,1,rep,name=cells" json:"cells,omitempty"`
+	// / Spans of the cells in the file, in the same order as the cells. Empty if the table is not from a spec file.
+	CellSpans        []*Span `protobuf:"bytes,2,rep,name=cellSpans" json:"cellSpans,omitempty"`
+	XXX_unrecognized []byte  `json:"-"`
 }
 
 func (m *ProtoTableRow) Reset()         { *m = ProtoTableRow{} }
@@ -662,6 +765,13 @@ func (m *ProtoTableRow) GetCells() []string {
 	return nil
 }
 
+func (m *ProtoTableRow) GetCellSpans() []*Span {
+	if m != nil {
+		return m.CellSpans
+	}
+	return nil
+}
+
 // / A proto object representing Step Execution result
 type ProtoStepExecutionResult struct {
 	// / The actual result of the execution
@@ -830,8 +940,10 @@ type ProtoSuiteResult struct {
 	// / Project name
 	ProjectName *string `protobuf:"bytes,10,req,name=projectName" json:"projectName,omitempty"`
 	// / Timestamp of when execution started
-	Timestamp        *string `protobuf:"bytes,11,req,name=timestamp" json:"timestamp,omitempty"`
-	XXX_unrecognized []byte  `json:"-"`
+	Timestamp *string `protobuf:"bytes,11,req,name=timestamp" json:"timestamp,omitempty"`
+	// / Count of specifications skipped with a skip marker
+	SpecsSkippedCount *int32 `protobuf:"varint,12,opt,name=specsSkippedCount" json:"specsSkippedCount,omitempty"`
+	XXX_unrecognized  []byte `json:"-"`
 }
 
 func (m *ProtoSuiteResult) Reset()         { *m = ProtoSuiteResult{} }
@@ -915,6 +1027,13 @@ func (m *ProtoSuiteResult) GetTimestamp() string {
 	return ""
 }
 
+func (m *ProtoSuiteResult) GetSpecsSkippedCount() int32 {
+	if m != nil && m.SpecsSkippedCount != nil {
+		return *m.SpecsSkippedCount
+	}
+	return 0
+}
+
 // / A proto object representing the result of Spec execution.
 type ProtoSpecResult struct {
 	// / Represents the corresponding Specification
@@ -928,8 +1047,14 @@ type ProtoSpecResult struct {
 	// / Holds the row numbers, which caused the execution to fail.
 	FailedDataTableRows []int32 `protobuf:"varint,5,rep,name=failedDataTableRows" json:"failedDataTableRows,omitempty"`
 	// / Holds the time taken for executing the spec.
-	ExecutionTime    *int64 `protobuf:"varint,6,opt,name=executionTime" json:"executionTime,omitempty"`
-	XXX_unrecognized []byte `json:"-"`
+	ExecutionTime *int64 `protobuf:"varint,6,opt,name=executionTime" json:"executionTime,omitempty"`
+	// / Flag to indicate if the Specification was skipped instead of being executed.
+	Skipped *bool `protobuf:"varint,7,opt,name=skipped" json:"skipped,omitempty"`
+	// / The reason for skipping the Specification. Valid only if Skipped = true
+	SkipReason *string `protobuf:"bytes,8,opt,name=skipReason" json:"skipReason,omitempty"`
+	// / Count of skipped Scenarios
+	ScenarioSkippedCount *int32 `protobuf:"varint,9,opt,name=scenarioSkippedCount" json:"scenarioSkippedCount,omitempty"`
+	XXX_unrecognized     []byte `json:"-"`
 }
 
 func (m *ProtoSpecResult) Reset()         { *m = ProtoSpecResult{} }
@@ -978,6 +1103,27 @@ func (m *ProtoSpecResult) GetExecutionTime() int64 {
 	return 0
 }
 
+func (m *ProtoSpecResult) GetSkipped() bool {
+	if m != nil && m.Skipped != nil {
+		return *m.Skipped
+	}
+	return false
+}
+
+func (m *ProtoSpecResult) GetSkipReason() string {
+	if m != nil && m.SkipReason != nil {
+		return *m.SkipReason
+	}
+	return ""
+}
+
+func (m *ProtoSpecResult) GetScenarioSkippedCount() int32 {
+	if m != nil && m.ScenarioSkippedCount != nil {
+		return *m.ScenarioSkippedCount
+	}
+	return 0
+}
+
 // / A proto object representing a Step value.
 type ProtoStepValue struct {
 	// / The actual string value describing he Step
@@ -1014,6 +1160,78 @@ func (m *ProtoStepValue) GetParameters() []string {
 	return nil
 }
 
+// / Position of an element in a file. Lines and characters start from 1, and the end is the position of the last character of the element.
+type Span struct {
+	// / Line where the element starts
+	Start *int32 `protobuf:"varint,1,req,name=start" json:"start,omitempty"`
+	// / Line where the element ends
+	End *int32 `protobuf:"varint,2,req,name=end" json:"end,omitempty"`
+	// / Character in the start line where the element starts
+	StartChar *int32 `protobuf:"varint,3,req,name=startChar" json:"startChar,omitempty"`
+	// / Character in the end line where the element ends
+	EndChar          *int32 `protobuf:"varint,4,req,name=endChar" json:"endChar,omitempty"`
+	XXX_unrecognized []byte `json:"-"`
+}
+
+func (m *Span) Reset()         { *m = Span{} }
+func (m *Span) String() string { return proto.CompactTextString(m) }
+func (*Span) ProtoMessage()    {}
+
+func (m *Span) GetStart() int32 {
+	if m != nil && m.Start != nil {
+		return *m.Start
+	}
+	return 0
+}
+
+func (m *Span) GetEnd() int32 {
+	if m != nil && m.End != nil {
+		return *m.End
+	}
+	return 0
+}
+
+func (m *Span) GetStartChar() int32 {
+	if m != nil && m.StartChar != nil {
+		return *m.StartChar
+	}
+	return 0
+}
+
+func (m *Span) GetEndChar() int32 {
+	if m != nil && m.EndChar != nil {
+		return *m.EndChar
+	}
+	return 0
+}
+
+// / A key value pair of the metadata of a Specification.
+type MetadataEntry struct {
+	// / Key of the metadata
+	Key *string `protobuf:"bytes,1,req,name=key" json:"key,omitempty"`
+	// / Value of the metadata
+	Value            *string `protobuf:"bytes,2,req,name=value" json:"value,omitempty"`
+	XXX_unrecognized []byte  `json:"-"`
+}
+
+func (m *MetadataEntry) Reset()         { *m = MetadataEntry{} }
+func (m *MetadataEntry) String() string { return proto.CompactTextString(m) }
+func (*MetadataEntry) ProtoMessage()    {}
+
+func (m *MetadataEntry) GetKey() string {
+	if m != nil && m.Key != nil {
+		return *m.Key
+	}
+	return ""
+}
+
+func (m *MetadataEntry) GetValue() string {
+	if m != nil && m.Value != nil {
+		return *m.Value
+	}
+	return ""
+}
+
 func init() {
 	proto.RegisterEnum("gauge.messages.ProtoItem_ItemType", ProtoItem_ItemType_name, ProtoItem_ItemType_value)
 	proto.RegisterEnum("gauge.messages.Fragment_FragmentType", Fragment_FragmentType_name, Fragment_FragmentType_value)
@@ -44,6 +44,76 @@ func (s *MySuite) TestToObtainIndexedSpecName1(c *C) {
 	c.Assert(scenarioNum, Equals, 67342)
 }
 
+func (s *MySuite) TestToCheckIfItsTableRowsSpec(c *C) {
+	c.Assert(isTableRowsSpec("specs/hello_world.spec:rows=4,17"), Equals, true)
+	c.Assert(isTableRowsSpec("specs/hello_world.md:rows=1-3"), Equals, true)
+	c.Assert(isTableRowsSpec("specs/hello_world.spec:rows="), Equals, false)
+	c.Assert(isTableRowsSpec("specs/hello_world.spec:4"), Equals, false)
+	c.Assert(isTableRowsSpec("specs/hello_world.spec"), Equals, false)
+
+	specName, rows := getTableRowsSpec("specs/hello_world.spec:rows=4,7-9")
+	c.Assert(specName, Equals, "specs/hello_world.spec")
+	c.Assert(rows, Equals, "4,7-9")
+}
+
+func (s *MySuite) TestSelectDataTableRows(c *C) {
+	specText := SpecBuilder().specHeading("spec heading").
+		tableHeader("id", "browser").
+		tableRow("1", "chrome").
+		tableRow("2", "firefox").
+		tableRow("3", "chrome").
+		tableRow("4", "chrome").
+		scenarioHeading("First scenario").
+		step("a step <id>").String()
+	spec, _ := new(specParser).parse(specText, new(conceptDictionary))
+
+	rows, _ := selectDataTableRows(spec, "", "")
+	c.Assert(rows, DeepEquals, []int{0, 1, 2, 3})
+
+	rows, _ = selectDataTableRows(spec, "1-3", "browser=chrome")
+	c.Assert(rows, DeepEquals, []int{0, 2})
+
+	spec.dataTableRows = "2,4"
+	rows, _ = selectDataTableRows(spec, "1", "")
+	c.Assert(rows, DeepEquals, []int{1, 3})
+
+	rows, err := selectDataTableRows(spec, "", "os=linux")
+	c.Assert(err, IsNil)
+	c.Assert(rows, DeepEquals, []int{})
+
+	_, err = selectDataTableRows(spec, "", "browser")
+	c.Assert(err.Error(), Equals, "Table filter 'browser' should be of the form column=value.")
+}
+
+func (s *MySuite) TestTableRowsFilterDropsSpecsWithoutMatchingRows(c *C) {
+	chromeSpec, _ := new(specParser).parse(SpecBuilder().specHeading("chrome spec").tableHeader("browser").tableRow("chrome").
+		scenarioHeading("scenario").step("a step").String(), new(conceptDictionary))
+	firefoxSpec, _ := new(specParser).parse(SpecBuilder().specHeading("firefox spec").tableHeader("browser").tableRow("firefox").
+		scenarioHeading("scenario").step("a step").String(), new(conceptDictionary))
+	plainSpec, _ := new(specParser).parse(SpecBuilder().specHeading("plain spec").
+		scenarioHeading("scenario").step("a step").String(), new(conceptDictionary))
+	osSpec, _ := new(specParser).parse(SpecBuilder().specHeading("os spec").tableHeader("os").tableRow("linux").
+		scenarioHeading("scenario").step("a step").String(), new(conceptDictionary))
+
+	specs := (&tableRowsFilter{tableFilter: "browser=chrome"}).filter([]*specification{chromeSpec, firefoxSpec, plainSpec, osSpec})
+
+	c.Assert(len(specs), Equals, 2)
+	c.Assert(specs[0], Equals, chromeSpec)
+	c.Assert(specs[1], Equals, plainSpec)
+}
+
+func (s *MySuite) TestTableRowsFilterWithoutAFilterKeepsSpecsWithValidRows(c *C) {
+	tableSpec, _ := new(specParser).parse(SpecBuilder().specHeading("table spec").tableHeader("browser").tableRow("chrome").tableRow("firefox").
+		scenarioHeading("scenario").step("a step").String(), new(conceptDictionary))
+	plainSpec, _ := new(specParser).parse(SpecBuilder().specHeading("plain spec").
+		scenarioHeading("scenario").step("a step").String(), new(conceptDictionary))
+
+	specs := (&tableRowsFilter{tableRows: "2"}).filter([]*specification{tableSpec, plainSpec})
+
+	c.Assert(len(specs), Equals, 2)
+	c.Assert(specs[0], Equals, tableSpec)
+}
+
 func (s *MySuite) TestToCheckTagsInSpecLevel(c *C) {
 	tokens := []*token{
 		&token{kind: specKind, value: "Spec Heading", lineNo: 1},
@@ -130,7 +200,7 @@ func (s *MySuite) TestToRunSpecificSetOfSpecs(c *C) {
 	value := 6
 	value1 := 3
 
-	groupFilter := &specsGroupFilter{value1, value}
+	groupFilter := &specsGroupFilter{value1, value, ""}
 	specsToExecute := groupFilter.filter(specs)
 
 	c.Assert(len(specsToExecute), Equals, 1)
@@ -157,7 +227,7 @@ func (s *MySuite) TestToRunSpecificSetOfSpecsGivesSameSpecsEverytime(c *C) {
 
 	value := 3
 
-	groupFilter := &specsGroupFilter{value, value}
+	groupFilter := &specsGroupFilter{value, value, ""}
 	specsToExecute1 := groupFilter.filter(specs)
 	c.Assert(len(specsToExecute1), Equals, 2)
 
@@ -180,13 +250,13 @@ func (s *MySuite) TestToRunSpecificSetOfSpecsGivesEmptySpecsIfDistributableNumbe
 
 	value := 1
 	value1 := 3
-	groupFilter := &specsGroupFilter{value1, value}
+	groupFilter := &specsGroupFilter{value1, value, ""}
 	specsToExecute1 := groupFilter.filter(specs)
 	c.Assert(len(specsToExecute1), Equals, 0)
 
 	value = 1
 	value1 = -3
-	groupFilter = &specsGroupFilter{value1, value}
+	groupFilter = &specsGroupFilter{value1, value, ""}
 	specsToExecute1 = groupFilter.filter(specs)
 	c.Assert(len(specsToExecute1), Equals, 0)
 }
@@ -50,6 +50,21 @@ func getIndex(specSource string) []int {
 	return nil
 }
 
+// A spec argument can select the data table rows to execute. Eg: specs/hello.spec:rows=1,3,7-9
+func isTableRowsSpec(specSource string) bool {
+	return getTableRowsIndex(specSource) != nil
+}
+
+func getTableRowsSpec(specSource string) (string, string) {
+	index := getTableRowsIndex(specSource)
+	return specSource[index[2]:index[3]], specSource[index[4]:index[5]]
+}
+
+func getTableRowsIndex(specSource string) []int {
+	re, _ := regexp.Compile("^(.*" + regexp.QuoteMeta(getTypeOfSpecFile(specSource)) + "):rows=([0-9, -]+)$")
+	return re.FindStringSubmatchIndex(specSource)
+}
+
 func getTypeOfSpecFile(specSource string) string {
 	for ext, accepted := range util.AcceptedExtensions {
 		if accepted {
@@ -0,0 +1,246 @@
+// Copyright 2015 ThoughtWorks, Inc.
+
+// This file is part of Gauge.
+
+// Gauge is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+
+// Gauge is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+
+// You should have received a copy of the GNU General Public License
+// along with Gauge.  If not, see <http://www.gnu.org/licenses/>.
+
+package main
+
+import (
+	"encoding/xml"
+	"fmt"
+	"github.com/getgauge/common"
+	"github.com/getgauge/gauge/gauge_messages"
+	"github.com/getgauge/gauge/logger"
+	"io/ioutil"
+	"strings"
+)
+
+const (
+	beforeSuiteHook    = "Before Suite"
+	afterSuiteHook     = "After Suite"
+	beforeSpecHook     = "Before Spec"
+	afterSpecHook      = "After Spec"
+	beforeScenarioHook = "Before Scenario"
+	afterScenarioHook  = "After Scenario"
+	beforeStepHook     = "Before Step"
+	afterStepHook      = "After Step"
+)
+
+type junitTestSuites struct {
+	XMLName    xml.Name          `xml:"testsuites"`
+	Name       string            `xml:"name,attr"`
+	Tests      int               `xml:"tests,attr"`
+	Failures   int               `xml:"failures,attr"`
+	Errors     int               `xml:"errors,attr"`
+	Skipped    int               `xml:"skipped,attr"`
+	Time       string            `xml:"time,attr"`
+	TestSuites []*junitTestSuite `xml:"testsuite"`
+}
+
+type junitTestSuite struct {
+	XMLName   xml.Name         `xml:"testsuite"`
+	Id        int              `xml:"id,attr"`
+	Name      string           `xml:"name,attr"`
+	Package   string           `xml:"package,attr,omitempty"`
+	Tests     int              `xml:"tests,attr"`
+	Failures  int              `xml:"failures,attr"`
+	Errors    int              `xml:"errors,attr"`
+	Skipped   int              `xml:"skipped,attr"`
+	Time      string           `xml:"time,attr"`
+	Timestamp string           `xml:"timestamp,attr,omitempty"`
+	TestCases []*junitTestCase `xml:"testcase"`
+}
+
+type junitTestCase struct {
+	XMLName   xml.Name      `xml:"testcase"`
+	ClassName string        `xml:"classname,attr"`
+	Name      string        `xml:"name,attr"`
+	Time      string        `xml:"time,attr"`
+	Failure   *junitFailure `xml:"failure,omitempty"`
+	Error     *junitFailure `xml:"error,omitempty"`
+	Skipped   *junitSkipped `xml:"skipped,omitempty"`
+}
+
+type junitSkipped struct {
+	Message string `xml:"message,attr"`
+}
+
+type junitFailure struct {
+	Message  string `xml:"message,attr"`
+	Type     string `xml:"type,attr"`
+	Contents string `xml:",chardata"`
+}
+
+type executionFailure struct {
+	source       string
+	errorMessage string
+	stackTrace   string
+	isHook       bool
+}
+
+func writeJUnitReport(fileName string, protoSuiteResult *gauge_messages.ProtoSuiteResult) {
+	contents, err := createJUnitReport(protoSuiteResult)
+	if err == nil {
+		err = ioutil.WriteFile(fileName, contents, common.NewFilePermissions)
+	}
+	if err != nil {
+		logger.Log.Error("Failed to write JUnit report to %s. %s\n", fileName, err.Error())
+		return
+	}
+	logger.Log.Info("JUnit report written to %s\n", fileName)
+}
+
+func createJUnitReport(protoSuiteResult *gauge_messages.ProtoSuiteResult) ([]byte, error) {
+	testSuites := &junitTestSuites{Name: protoSuiteResult.GetProjectName(), Time: formatJUnitTime(protoSuiteResult.GetExecutionTime())}
+	addSuiteHookTestSuite(testSuites, beforeSuiteHook, protoSuiteResult.GetPreHookFailure(), protoSuiteResult.GetTimestamp())
+	for _, protoSpecResult := range protoSuiteResult.GetSpecResults() {
+		testSuites.TestSuites = append(testSuites.TestSuites, newJUnitTestSuite(len(testSuites.TestSuites), protoSpecResult, protoSuiteResult.GetTimestamp()))
+	}
+	addSuiteHookTestSuite(testSuites, afterSuiteHook, protoSuiteResult.GetPostHookFailure(), protoSuiteResult.GetTimestamp())
+	for _, testSuite := range testSuites.TestSuites {
+		testSuites.Tests += testSuite.Tests
+		testSuites.Failures += testSuite.Failures
+		testSuites.Errors += testSuite.Errors
+		testSuites.Skipped += testSuite.Skipped
+	}
+	contents, err := xml.MarshalIndent(testSuites, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	return append([]byte(xml.Header), contents...), nil
+}
+
+func addSuiteHookTestSuite(testSuites *junitTestSuites, hookName string, hookFailure *gauge_messages.ProtoHookFailure, timestamp string) {
+	if hookFailure == nil {
+		return
+	}
+	testSuite := &junitTestSuite{Id: len(testSuites.TestSuites), Name: hookName, Time: formatJUnitTime(0), Timestamp: timestamp}
+	testSuite.addTestCase(&junitTestCase{ClassName: hookName, Name: hookName, Time: formatJUnitTime(0)}, []*executionFailure{newHookFailure(hookName, hookFailure)})
+	testSuites.TestSuites = append(testSuites.TestSuites, testSuite)
+}
+
+func newJUnitTestSuite(id int, protoSpecResult *gauge_messages.ProtoSpecResult, timestamp string) *junitTestSuite {
+	protoSpec := protoSpecResult.GetProtoSpec()
+	specHeading := protoSpec.GetSpecHeading()
+	testSuite := &junitTestSuite{
+		Id:        id,
+		Name:      specHeading,
+		Package:   protoSpec.GetFileName(),
+		Time:      formatJUnitTime(protoSpecResult.GetExecutionTime()),
+		Timestamp: timestamp,
+	}
+	if protoSpec.GetPreHookFailure() != nil {
+		testSuite.addTestCase(&junitTestCase{ClassName: specHeading, Name: beforeSpecHook, Time: formatJUnitTime(0)}, []*executionFailure{newHookFailure(beforeSpecHook, protoSpec.GetPreHookFailure())})
+	}
+	for _, item := range protoSpec.GetItems() {
+		switch item.GetItemType() {
+		case gauge_messages.ProtoItem_Scenario:
+			testSuite.addScenario(specHeading, item.GetScenario(), item.GetScenario().GetScenarioHeading())
+		case gauge_messages.ProtoItem_TableDrivenScenario:
+			for rowIndex, scenario := range item.GetTableDrivenScenario().GetScenarios() {
+				testSuite.addScenario(specHeading, scenario, fmt.Sprintf("%s [row %d]", scenario.GetScenarioHeading(), rowIndex+1))
+			}
+		}
+	}
+	if protoSpec.GetPostHookFailure() != nil {
+		testSuite.addTestCase(&junitTestCase{ClassName: specHeading, Name: afterSpecHook, Time: formatJUnitTime(0)}, []*executionFailure{newHookFailure(afterSpecHook, protoSpec.GetPostHookFailure())})
+	}
+	return testSuite
+}
+
+func (testSuite *junitTestSuite) addScenario(specHeading string, scenario *gauge_messages.ProtoScenario, testCaseName string) {
+	if scenario.GetSkipped() {
+		testSuite.Tests++
+		testSuite.Skipped++
+		testSuite.TestCases = append(testSuite.TestCases, &junitTestCase{ClassName: specHeading, Name: testCaseName, Time: formatJUnitTime(0), Skipped: &junitSkipped{Message: scenario.GetSkipReason()}})
+		return
+	}
+	failures := make([]*executionFailure, 0)
+	if scenario.GetPreHookFailure() != nil {
+		failures = append(failures, newHookFailure(beforeScenarioHook, scenario.GetPreHookFailure()))
+	}
+	failures = append(failures, getItemFailures(scenario.GetContexts())...)
+	failures = append(failures, getItemFailures(scenario.GetScenarioItems())...)
+	failures = append(failures, getItemFailures(scenario.GetTearDownSteps())...)
+	if scenario.GetPostHookFailure() != nil {
+		failures = append(failures, newHookFailure(afterScenarioHook, scenario.GetPostHookFailure()))
+	}
+	testCase := &junitTestCase{ClassName: specHeading, Name: testCaseName, Time: formatJUnitTime(scenario.GetExecutionTime())}
+	testSuite.addTestCase(testCase, failures)
+}
+
+// addTestCase records the test case as an error if any hook failed, and as a failure if only steps failed.
+func (testSuite *junitTestSuite) addTestCase(testCase *junitTestCase, failures []*executionFailure) {
+	testSuite.Tests++
+	testSuite.TestCases = append(testSuite.TestCases, testCase)
+	if len(failures) == 0 {
+		return
+	}
+	contents := make([]string, 0)
+	hookFailed := false
+	for _, failure := range failures {
+		contents = append(contents, fmt.Sprintf("%s: %s\n%s", failure.source, failure.errorMessage, failure.stackTrace))
+		hookFailed = hookFailed || failure.isHook
+	}
+	junitFailure := &junitFailure{Message: failures[0].errorMessage, Contents: strings.Join(contents, "\n")}
+	if hookFailed {
+		junitFailure.Type = "HookFailure"
+		testCase.Error = junitFailure
+		testSuite.Errors++
+	} else {
+		junitFailure.Type = "StepFailure"
+		testCase.Failure = junitFailure
+		testSuite.Failures++
+	}
+}
+
+func getItemFailures(items []*gauge_messages.ProtoItem) []*executionFailure {
+	failures := make([]*executionFailure, 0)
+	for _, item := range items {
+		switch item.GetItemType() {
+		case gauge_messages.ProtoItem_Step:
+			failures = append(failures, getStepFailures(item.GetStep().GetActualText(), item.GetStep().GetStepExecutionResult())...)
+		case gauge_messages.ProtoItem_Concept:
+			failures = append(failures, getItemFailures(item.GetConcept().GetSteps())...)
+		}
+	}
+	return failures
+}
+
+func getStepFailures(stepText string, stepExecutionResult *gauge_messages.ProtoStepExecutionResult) []*executionFailure {
+	failures := make([]*executionFailure, 0)
+	if stepExecutionResult == nil {
+		return failures
+	}
+	if stepExecutionResult.GetPreHookFailure() != nil {
+		failures = append(failures, newHookFailure(beforeStepHook, stepExecutionResult.GetPreHookFailure()))
+	}
+	executionResult := stepExecutionResult.GetExecutionResult()
+	if executionResult.GetFailed() {
+		failures = append(failures, &executionFailure{source: stepText, errorMessage: executionResult.GetErrorMessage(), stackTrace: executionResult.GetStackTrace()})
+	}
+	if stepExecutionResult.GetPostHookFailure() != nil {
+		failures = append(failures, newHookFailure(afterStepHook, stepExecutionResult.GetPostHookFailure()))
+	}
+	return failures
+}
+
+func newHookFailure(hookName string, hookFailure *gauge_messages.ProtoHookFailure) *executionFailure {
+	return &executionFailure{source: hookName, errorMessage: hookFailure.GetErrorMessage(), stackTrace: hookFailure.GetStackTrace(), isHook: true}
+}
+
+func formatJUnitTime(executionTimeInMillis int64) string {
+	return fmt.Sprintf("%.3f", float64(executionTimeInMillis)/1000)
+}
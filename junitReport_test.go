@@ -0,0 +1,126 @@
+// Copyright 2015 ThoughtWorks, Inc.
+
+// This file is part of Gauge.
+
+// Gauge is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+
+// Gauge is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+
+// You should have received a copy of the GNU General Public License
+// along with Gauge.  If not, see <http://www.gnu.org/licenses/>.
+
+package main
+
+import (
+	"encoding/xml"
+	"github.com/getgauge/gauge/gauge_messages"
+	"github.com/golang/protobuf/proto"
+	. "gopkg.in/check.v1"
+)
+
+func newProtoStepItem(stepText string, failed bool, errorMessage string) *gauge_messages.ProtoItem {
+	executionResult := &gauge_messages.ProtoExecutionResult{Failed: proto.Bool(failed), ErrorMessage: proto.String(errorMessage), StackTrace: proto.String("stacktrace"), ExecutionTime: proto.Int64(10)}
+	step := &gauge_messages.ProtoStep{ActualText: proto.String(stepText), StepExecutionResult: &gauge_messages.ProtoStepExecutionResult{ExecutionResult: executionResult}}
+	return &gauge_messages.ProtoItem{ItemType: gauge_messages.ProtoItem_Step.Enum(), Step: step}
+}
+
+func newProtoScenarioItem(heading string, failed bool, items ...*gauge_messages.ProtoItem) *gauge_messages.ProtoItem {
+	scenario := &gauge_messages.ProtoScenario{ScenarioHeading: proto.String(heading), Failed: proto.Bool(failed), ScenarioItems: items, ExecutionTime: proto.Int64(1500)}
+	return &gauge_messages.ProtoItem{ItemType: gauge_messages.ProtoItem_Scenario.Enum(), Scenario: scenario}
+}
+
+func (s *MySuite) TestJUnitReportHasTestSuitePerSpecAndTestCasePerScenario(c *C) {
+	protoSpec := &gauge_messages.ProtoSpec{SpecHeading: proto.String("Spec heading"), FileName: proto.String("specs/foo.spec")}
+	protoSpec.Items = []*gauge_messages.ProtoItem{
+		newProtoScenarioItem("passing", false, newProtoStepItem("step one", false, "")),
+		newProtoScenarioItem("failing", true, newProtoStepItem("step two", true, "assertion failed")),
+	}
+	protoSuiteResult := &gauge_messages.ProtoSuiteResult{ProjectName: proto.String("project"), ExecutionTime: proto.Int64(3000), SpecResults: []*gauge_messages.ProtoSpecResult{&gauge_messages.ProtoSpecResult{ProtoSpec: protoSpec, ExecutionTime: proto.Int64(3000)}}}
+
+	contents, err := createJUnitReport(protoSuiteResult)
+	c.Assert(err, IsNil)
+	testSuites := new(junitTestSuites)
+	c.Assert(xml.Unmarshal(contents, testSuites), IsNil)
+
+	c.Assert(testSuites.Name, Equals, "project")
+	c.Assert(testSuites.Tests, Equals, 2)
+	c.Assert(testSuites.Failures, Equals, 1)
+	c.Assert(testSuites.Time, Equals, "3.000")
+	c.Assert(len(testSuites.TestSuites), Equals, 1)
+	testSuite := testSuites.TestSuites[0]
+	c.Assert(testSuite.Name, Equals, "Spec heading")
+	c.Assert(testSuite.Package, Equals, "specs/foo.spec")
+	c.Assert(len(testSuite.TestCases), Equals, 2)
+	c.Assert(testSuite.TestCases[0].Name, Equals, "passing")
+	c.Assert(testSuite.TestCases[0].Time, Equals, "1.500")
+	c.Assert(testSuite.TestCases[0].Failure, IsNil)
+	c.Assert(testSuite.TestCases[1].Name, Equals, "failing")
+	c.Assert(testSuite.TestCases[1].Failure.Message, Equals, "assertion failed")
+	c.Assert(testSuite.TestCases[1].Failure.Contents, Equals, "step two: assertion failed\nstacktrace")
+}
+
+func (s *MySuite) TestJUnitReportHasTestCasePerTableDrivenRow(c *C) {
+	firstRow := &gauge_messages.ProtoScenario{ScenarioHeading: proto.String("scenario"), Failed: proto.Bool(false)}
+	secondRow := &gauge_messages.ProtoScenario{ScenarioHeading: proto.String("scenario"), Failed: proto.Bool(true), ScenarioItems: []*gauge_messages.ProtoItem{newProtoStepItem("step", true, "row failed")}}
+	tableDrivenItem := &gauge_messages.ProtoItem{ItemType: gauge_messages.ProtoItem_TableDrivenScenario.Enum(), TableDrivenScenario: &gauge_messages.ProtoTableDrivenScenario{Scenarios: []*gauge_messages.ProtoScenario{firstRow, secondRow}}}
+	protoSpec := &gauge_messages.ProtoSpec{SpecHeading: proto.String("Spec heading"), Items: []*gauge_messages.ProtoItem{tableDrivenItem}}
+
+	testSuite := newJUnitTestSuite(0, &gauge_messages.ProtoSpecResult{ProtoSpec: protoSpec}, "")
+
+	c.Assert(testSuite.Tests, Equals, 2)
+	c.Assert(testSuite.Failures, Equals, 1)
+	c.Assert(testSuite.TestCases[0].Name, Equals, "scenario [row 1]")
+	c.Assert(testSuite.TestCases[1].Name, Equals, "scenario [row 2]")
+	c.Assert(testSuite.TestCases[1].Failure.Message, Equals, "row failed")
+}
+
+func (s *MySuite) TestJUnitReportRecordsSkippedScenarios(c *C) {
+	skippedItem := newProtoScenarioItem("skipped", false)
+	skippedItem.Scenario.Skipped = proto.Bool(true)
+	skippedItem.Scenario.SkipReason = proto.String("known issue")
+	protoSpec := &gauge_messages.ProtoSpec{SpecHeading: proto.String("Spec heading"), Items: []*gauge_messages.ProtoItem{newProtoScenarioItem("passing", false), skippedItem}}
+	protoSuiteResult := &gauge_messages.ProtoSuiteResult{SpecResults: []*gauge_messages.ProtoSpecResult{&gauge_messages.ProtoSpecResult{ProtoSpec: protoSpec}}}
+
+	contents, err := createJUnitReport(protoSuiteResult)
+	c.Assert(err, IsNil)
+	testSuites := new(junitTestSuites)
+	c.Assert(xml.Unmarshal(contents, testSuites), IsNil)
+
+	c.Assert(testSuites.Tests, Equals, 2)
+	c.Assert(testSuites.Skipped, Equals, 1)
+	c.Assert(testSuites.Failures, Equals, 0)
+	testCases := testSuites.TestSuites[0].TestCases
+	c.Assert(testCases[0].Skipped, IsNil)
+	c.Assert(testCases[1].Skipped.Message, Equals, "known issue")
+}
+
+func (s *MySuite) TestJUnitReportRecordsHookFailuresAsErrors(c *C) {
+	scenarioItem := newProtoScenarioItem("scenario", true)
+	scenarioItem.Scenario.PreHookFailure = &gauge_messages.ProtoHookFailure{ErrorMessage: proto.String("before scenario failed"), StackTrace: proto.String("hook stacktrace")}
+	protoSpec := &gauge_messages.ProtoSpec{SpecHeading: proto.String("Spec heading"), Items: []*gauge_messages.ProtoItem{scenarioItem}}
+	protoSpec.PostHookFailure = &gauge_messages.ProtoHookFailure{ErrorMessage: proto.String("after spec failed"), StackTrace: proto.String("")}
+	protoSuiteResult := &gauge_messages.ProtoSuiteResult{SpecResults: []*gauge_messages.ProtoSpecResult{&gauge_messages.ProtoSpecResult{ProtoSpec: protoSpec}}}
+	protoSuiteResult.PreHookFailure = &gauge_messages.ProtoHookFailure{ErrorMessage: proto.String("before suite failed"), StackTrace: proto.String("")}
+
+	contents, err := createJUnitReport(protoSuiteResult)
+	c.Assert(err, IsNil)
+	testSuites := new(junitTestSuites)
+	c.Assert(xml.Unmarshal(contents, testSuites), IsNil)
+
+	c.Assert(testSuites.Errors, Equals, 3)
+	c.Assert(len(testSuites.TestSuites), Equals, 2)
+	c.Assert(testSuites.TestSuites[0].Name, Equals, beforeSuiteHook)
+	c.Assert(testSuites.TestSuites[0].TestCases[0].Error.Message, Equals, "before suite failed")
+	specTestCases := testSuites.TestSuites[1].TestCases
+	c.Assert(len(specTestCases), Equals, 2)
+	c.Assert(specTestCases[0].Error.Type, Equals, "HookFailure")
+	c.Assert(specTestCases[0].Error.Contents, Equals, "Before Scenario: before scenario failed\nhook stacktrace")
+	c.Assert(specTestCases[1].Name, Equals, afterSpecHook)
+	c.Assert(specTestCases[1].Error.Message, Equals, "after spec failed")
+}
@@ -0,0 +1,211 @@
+// Copyright 2015 ThoughtWorks, Inc.
+
+// This file is part of Gauge.
+
+// Gauge is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+
+// Gauge is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+
+// You should have received a copy of the GNU General Public License
+// along with Gauge.  If not, see <http://www.gnu.org/licenses/>.
+
+package main
+
+import (
+	"regexp"
+	"strings"
+)
+
+// Specs are markdown documents. Lines that are part of a code block, a block quote or an html block are not parsed
+// as spec elements, and are kept as comments so that they are written back as they are when the spec is formatted.
+
+type markdownBlockKind int
+
+const (
+	fencedCodeBlock markdownBlockKind = iota
+	indentedCodeBlock
+	blockQuote
+	htmlBlock
+)
+
+const indentedCodeWidth = 4
+
+type markdownBlock struct {
+	kind markdownBlockKind
+	//opening fence of a fenced code block
+	fence string
+	//text which closes an html block. Html blocks without one are closed by a blank line
+	endMarker *regexp.Regexp
+}
+
+var (
+	fenceRegex           = regexp.MustCompile("^(`{3,}|~{3,})")
+	thematicBreakRegex   = regexp.MustCompile(`^((\*[ \t]*){3,}|(-[ \t]*){3,}|(_[ \t]*){3,})$`)
+	listItemRegex        = regexp.MustCompile(`^([*+-]|[0-9]{1,9}[.)])([ \t]|$)`)
+	rawHtmlBlockRegex    = regexp.MustCompile(`(?i)^<(script|pre|style|textarea)([ \t>]|$)`)
+	htmlBlockTagRegex    = regexp.MustCompile(`(?i)^</?(address|article|aside|base|basefont|blockquote|body|caption|center|col|colgroup|dd|details|dialog|dir|div|dl|dt|fieldset|figcaption|figure|footer|form|frame|frameset|h[1-6]|head|header|hr|html|iframe|legend|li|link|main|menu|menuitem|nav|noframes|ol|optgroup|option|p|param|section|source|summary|table|tbody|td|tfoot|th|thead|title|tr|track|ul)([ \t>]|/>|$)`)
+	completeHtmlTagRegex = regexp.MustCompile(`^(<[A-Za-z][A-Za-z0-9-]*([ \t]+[A-Za-z_:][A-Za-z0-9_.:-]*([ \t]*=[ \t]*("[^"]*"|'[^']*'|[^ \t"'=<>` + "`" + `]+))?)*[ \t]*/?>|</[A-Za-z][A-Za-z0-9-]*[ \t]*>)[ \t]*$`)
+)
+
+// Returns whether the line is part of a markdown block, and keeps track of the block the parser is in
+func (parser *specParser) isMarkdownBlockLine(line string) bool {
+	if parser.markdownBlock != nil {
+		isPart, isClosed := parser.continuesMarkdownBlock(parser.markdownBlock, line)
+		if isClosed {
+			parser.markdownBlock = nil
+		}
+		if isPart {
+			return true
+		}
+		parser.markdownBlock = nil
+	}
+	text := strings.TrimSpace(line)
+	if text == "" || parser.isTextBlockStart(text) {
+		return false
+	}
+	parser.markdownBlock = parser.startMarkdownBlock(line)
+	if parser.markdownBlock == nil {
+		return false
+	}
+	if endMarker := parser.markdownBlock.endMarker; endMarker != nil && endMarker.MatchString(line) {
+		parser.markdownBlock = nil
+	}
+	return true
+}
+
+// Lines of a markdown block are kept as they are, except for blank lines which are read like any other blank line
+func markdownLineValue(line string) string {
+	if strings.TrimSpace(line) == "" {
+		return "\n"
+	}
+	return line
+}
+
+// Returns the markdown block started by the line, if any. Indented code and html blocks which are closed by a blank
+// line cannot interrupt a paragraph.
+func (parser *specParser) startMarkdownBlock(line string) *markdownBlock {
+	if indentation(line) >= indentedCodeWidth {
+		if parser.canStartIndentedCode(line) {
+			return &markdownBlock{kind: indentedCodeBlock}
+		}
+		return nil
+	}
+	text := strings.TrimSpace(line)
+	if fence := fenceRegex.FindString(text); fence != "" && !(fence[0] == '`' && strings.Contains(text[len(fence):], "`")) {
+		return &markdownBlock{kind: fencedCodeBlock, fence: fence}
+	}
+	if strings.HasPrefix(text, ">") {
+		return &markdownBlock{kind: blockQuote}
+	}
+	return parser.startHtmlBlock(text)
+}
+
+func (parser *specParser) startHtmlBlock(text string) *markdownBlock {
+	if matches := rawHtmlBlockRegex.FindStringSubmatch(text); matches != nil {
+		return &markdownBlock{kind: htmlBlock, endMarker: regexp.MustCompile("(?i)</" + matches[1] + ">")}
+	}
+	endMarkers := []struct{ start, end string }{{"<!--", "-->"}, {"<?", "?>"}, {"<![CDATA[", "]]>"}}
+	for _, marker := range endMarkers {
+		if strings.HasPrefix(text, marker.start) {
+			return &markdownBlock{kind: htmlBlock, endMarker: regexp.MustCompile(regexp.QuoteMeta(marker.end))}
+		}
+	}
+	if len(text) > 2 && strings.HasPrefix(text, "<!") && isASCIILetter(text[2]) {
+		return &markdownBlock{kind: htmlBlock, endMarker: regexp.MustCompile(">")}
+	}
+	if htmlBlockTagRegex.MatchString(text) || (completeHtmlTagRegex.MatchString(text) && !parser.isInParagraph()) {
+		return &markdownBlock{kind: htmlBlock}
+	}
+	return nil
+}
+
+// Steps, tags and tables are often indented in specs, and the formatter indents tables, so a line which is one of them
+// is still parsed as a spec element rather than as indented code
+func (parser *specParser) isIndentedSpecElement(line string) bool {
+	text := strings.TrimSpace(line)
+	isTag, _ := parser.checkTag(text)
+	return parser.isStep(text) || isTag || parser.isTableRow(text) || isVerticalTableMarker(line)
+}
+
+func (parser *specParser) canStartIndentedCode(line string) bool {
+	if parser.isIndentedSpecElement(line) || parser.isInParagraph() {
+		return false
+	}
+	for i := len(parser.tokens) - 1; i >= 0; i-- {
+		if previousToken := parser.tokens[i]; previousToken.kind != commentKind || previousToken.value != "\n" {
+			return previousToken.kind != stepKind && previousToken.kind != tableHeader && previousToken.kind != tableRow && previousToken.kind != textBlockKind
+		}
+	}
+	return true
+}
+
+// The previous line is text which is not part of any other element or markdown block
+func (parser *specParser) isInParagraph() bool {
+	if len(parser.tokens) == 0 || !isInState(parser.currentState, commentScope) {
+		return false
+	}
+	previousToken := parser.tokens[len(parser.tokens)-1]
+	return previousToken.kind == commentKind && previousToken.value != "\n"
+}
+
+// Returns whether the line is part of the block, and whether the block ends with it.
+func (parser *specParser) continuesMarkdownBlock(block *markdownBlock, line string) (bool, bool) {
+	text := strings.TrimSpace(line)
+	switch block.kind {
+	case fencedCodeBlock:
+		return true, indentation(line) < indentedCodeWidth && isClosingFence(text, block.fence)
+	case indentedCodeBlock:
+		return text == "" || (indentation(line) >= indentedCodeWidth && !parser.isIndentedSpecElement(line)), false
+	case blockQuote:
+		if text == "" {
+			return false, false
+		}
+		return strings.HasPrefix(text, ">") || !parser.startsNewBlock(line), false
+	case htmlBlock:
+		if block.endMarker == nil {
+			return text != "", false
+		}
+		return true, block.endMarker.MatchString(line)
+	}
+	return false, false
+}
+
+// A line which does not start a new block is a lazy continuation of the paragraph in a block quote
+func (parser *specParser) startsNewBlock(line string) bool {
+	text := strings.TrimSpace(line)
+	if indentation(line) >= indentedCodeWidth {
+		return false
+	}
+	return strings.HasPrefix(text, "#") || parser.isStep(text) || fenceRegex.MatchString(text) || thematicBreakRegex.MatchString(text) ||
+		listItemRegex.MatchString(text) || rawHtmlBlockRegex.MatchString(text) || htmlBlockTagRegex.MatchString(text) || strings.HasPrefix(text, "<!--")
+}
+
+func isClosingFence(text string, fence string) bool {
+	closingFence := strings.TrimRight(text, " \t")
+	return len(closingFence) >= len(fence) && strings.Trim(closingFence, fence[:1]) == ""
+}
+
+// Width of the leading whitespace, with tabs expanded to the next tab stop of 4 columns
+func indentation(line string) int {
+	width := 0
+	for _, char := range line {
+		if char == ' ' {
+			width++
+		} else if char == '\t' {
+			width += indentedCodeWidth - width%indentedCodeWidth
+		} else {
+			break
+		}
+	}
+	return width
+}
+
+func isASCIILetter(char byte) bool {
+	return (char >= 'a' && char <= 'z') || (char >= 'A' && char <= 'Z')
+}
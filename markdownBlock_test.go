@@ -0,0 +1,222 @@
+// Copyright 2015 ThoughtWorks, Inc.
+
+// This file is part of Gauge.
+
+// Gauge is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+
+// Gauge is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+
+// You should have received a copy of the GNU General Public License
+// along with Gauge.  If not, see <http://www.gnu.org/licenses/>.
+
+package main
+
+import (
+	. "gopkg.in/check.v1"
+)
+
+func tokenKinds(tokens []*token) []tokenKind {
+	kinds := make([]tokenKind, 0)
+	for _, token := range tokens {
+		kinds = append(kinds, token.kind)
+	}
+	return kinds
+}
+
+func (s *MySuite) TestLinesInFencedCodeBlockAreComments(c *C) {
+	specText := SpecBuilder().specHeading("Spec heading").
+		text("```").
+		text("* not a step").
+		text("## not a scenario").
+		text("").
+		text("|not|a table|").
+		text("````").
+		scenarioHeading("Scenario heading").
+		step("a step").
+		String()
+
+	tokens, err := new(specParser).generateTokens(specText)
+
+	c.Assert(err, IsNil)
+	c.Assert(tokenKinds(tokens), DeepEquals, []tokenKind{specKind, commentKind, commentKind, commentKind, commentKind, commentKind, commentKind, scenarioKind, stepKind})
+	c.Assert(tokens[2].value, Equals, "* not a step")
+	c.Assert(tokens[4].value, Equals, "\n")
+}
+
+func (s *MySuite) TestFencedCodeBlockIsClosedOnlyByAMatchingFence(c *C) {
+	specText := SpecBuilder().specHeading("Spec heading").
+		text("~~~~ text").
+		text("```").
+		text("* not a step").
+		text("~~~").
+		text("~~~~~").
+		step("a step").
+		String()
+
+	tokens, _ := new(specParser).generateTokens(specText)
+
+	c.Assert(tokenKinds(tokens), DeepEquals, []tokenKind{specKind, commentKind, commentKind, commentKind, commentKind, commentKind, stepKind})
+}
+
+func (s *MySuite) TestTextBlockOfAStepIsNotACodeBlock(c *C) {
+	specText := SpecBuilder().specHeading("Spec heading").
+		step("a step with a text block").
+		text("```").
+		text("text").
+		text("```").
+		step("a step").
+		String()
+
+	tokens, _ := new(specParser).generateTokens(specText)
+
+	c.Assert(tokenKinds(tokens), DeepEquals, []tokenKind{specKind, stepKind, textBlockKind, stepKind})
+}
+
+func (s *MySuite) TestLinesInIndentedCodeBlockAreComments(c *C) {
+	specText := SpecBuilder().specHeading("Spec heading").
+		text("").
+		text("    ## not a scenario").
+		text("").
+		text("\t# not a spec heading").
+		step("a step").
+		String()
+
+	tokens, _ := new(specParser).generateTokens(specText)
+
+	c.Assert(tokenKinds(tokens), DeepEquals, []tokenKind{specKind, commentKind, commentKind, commentKind, commentKind, stepKind})
+	c.Assert(tokens[2].value, Equals, "    ## not a scenario")
+}
+
+func (s *MySuite) TestIndentedStepsTagsAndTablesAreNotCode(c *C) {
+	specText := SpecBuilder().specHeading("Spec heading").
+		text("").
+		text("    some code").
+		text("    * a step").
+		text("").
+		text("    tags: tag1").
+		text("").
+		text("    |id|name|").
+		String()
+
+	tokens, _ := new(specParser).generateTokens(specText)
+
+	c.Assert(tokenKinds(tokens), DeepEquals, []tokenKind{specKind, commentKind, commentKind, stepKind, commentKind, tagKind, commentKind, tableHeader})
+}
+
+func (s *MySuite) TestIndentedStepsOfAScenarioAreParsed(c *C) {
+	specText := `Spec heading
+============
+Scenario
+--------
+    * first step
+    * second step
+`
+
+	spec, result := new(specParser).parse(specText, new(conceptDictionary))
+
+	c.Assert(result.ok, Equals, true)
+	c.Assert(len(spec.scenarios[0].steps), Equals, 2)
+	c.Assert(spec.scenarios[0].steps[1].value, Equals, "second step")
+}
+
+func (s *MySuite) TestIndentedLinesAfterAStepOrParagraphAreNotCode(c *C) {
+	specText := SpecBuilder().specHeading("Spec heading").
+		text("Some description").
+		text("    continued").
+		step("a step").
+		text("").
+		text("     |id|name|").
+		String()
+
+	tokens, _ := new(specParser).generateTokens(specText)
+
+	c.Assert(tokenKinds(tokens), DeepEquals, []tokenKind{specKind, commentKind, commentKind, stepKind, commentKind, tableHeader})
+}
+
+func (s *MySuite) TestLinesInBlockQuoteAreComments(c *C) {
+	specText := SpecBuilder().specHeading("Spec heading").
+		text("> Note").
+		text("> * not a step").
+		text("lazy continuation").
+		text("=================").
+		text("").
+		text("Spec heading").
+		text("============").
+		String()
+
+	tokens, _ := new(specParser).generateTokens(specText)
+
+	c.Assert(tokenKinds(tokens), DeepEquals, []tokenKind{specKind, commentKind, commentKind, commentKind, commentKind, commentKind, specKind})
+	c.Assert(tokens[4].value, Equals, "=================")
+}
+
+func (s *MySuite) TestBlockQuoteEndsAtStep(c *C) {
+	specText := SpecBuilder().specHeading("Spec heading").
+		text("> Note").
+		step("a step").
+		String()
+
+	tokens, _ := new(specParser).generateTokens(specText)
+
+	c.Assert(tokenKinds(tokens), DeepEquals, []tokenKind{specKind, commentKind, stepKind})
+}
+
+func (s *MySuite) TestLinesInHtmlBlocksAreComments(c *C) {
+	specText := SpecBuilder().specHeading("Spec heading").
+		text("<div>").
+		text("* not a step").
+		text("").
+		text("<!--").
+		text("* not a step").
+		text("-->").
+		text("<pre>").
+		text("").
+		text("* not a step").
+		text("</pre>").
+		step("a step").
+		String()
+
+	tokens, _ := new(specParser).generateTokens(specText)
+
+	c.Assert(tokenKinds(tokens), DeepEquals, []tokenKind{specKind, commentKind, commentKind, commentKind, commentKind, commentKind, commentKind, commentKind, commentKind, commentKind, commentKind, stepKind})
+}
+
+func (s *MySuite) TestSingleLineHtmlCommentDoesNotStartABlock(c *C) {
+	specText := SpecBuilder().specHeading("Spec heading").
+		text("<!-- a comment -->").
+		step("a step").
+		String()
+
+	tokens, _ := new(specParser).generateTokens(specText)
+
+	c.Assert(tokenKinds(tokens), DeepEquals, []tokenKind{specKind, commentKind, stepKind})
+}
+
+func (s *MySuite) TestFormattingKeepsMarkdownBlocksUnchanged(c *C) {
+	specText := `Spec heading
+============
+Some description
+
+    indented code  
+~~~
+* fenced code
+~~~
+> * a quote
+
+Scenario heading
+----------------
+* a step
+`
+
+	spec, result := new(specParser).parse(specText, new(conceptDictionary))
+
+	c.Assert(result.ok, Equals, true)
+	c.Assert(len(spec.scenarios[0].steps), Equals, 1)
+	c.Assert(formatSpecification(spec), Equals, specText)
+}
@@ -38,6 +38,8 @@ type parallelSpecExecution struct {
 	aggregateResult          *suiteResult
 	numberOfExecutionStreams int
 	writer                   executionLogger
+	failureThreshold         *failureThreshold
+	specParts                specParts
 }
 
 type streamExecError struct {
@@ -71,23 +73,38 @@ func (self *parallelInfo) isValid() bool {
 		logger.Log.Error("Invalid input(%s) to --n flag", strconv.Itoa(self.numberOfStreams))
 		return false
 	}
+	if *parallelScenarios && !self.inParallel {
+		logger.Log.Error("--parallel-scenarios can only be used along with --parallel. Eg: gauge -p --parallel-scenarios specs")
+		return false
+	}
 	return true
 }
 
 func (e *parallelSpecExecution) start() *suiteResult {
 	startTime := time.Now()
-	specCollections := e.distributeSpecs(e.numberOfExecutionStreams)
-	suiteResultChannel := make(chan *suiteResult, len(specCollections))
-	for i, specCollection := range specCollections {
-		go e.startSpecsExecution(specCollection, suiteResultChannel, nil, newParallelExecutionConsoleWriter(i+1))
+	if *parallelScenarios {
+		e.specifications, e.specParts = splitSpecs(e.specifications)
 	}
-	e.writer.Info("Executing in %s parallel streams.", strconv.Itoa(len(specCollections)))
+	numberOfStreams := e.numberOfStreams()
+	specs := newSpecQueue(e.specifications)
+	suiteResultChannel := make(chan *suiteResult, numberOfStreams)
+	for i := 0; i < numberOfStreams; i++ {
+		go e.startSpecsExecution(specs, suiteResultChannel, newParallelExecutionConsoleWriter(i+1))
+	}
+	e.writer.Info("Executing in %s parallel streams.", strconv.Itoa(numberOfStreams))
 	suiteResults := make([]*suiteResult, 0)
-	for _, _ = range specCollections {
+	for i := 0; i < numberOfStreams; i++ {
 		suiteResults = append(suiteResults, <-suiteResultChannel)
 	}
 
 	e.aggregateResult = e.aggregateResults(suiteResults)
+	if e.specParts != nil {
+		e.mergeSpecParts()
+	}
+	if remainingSpecs := specs.drain(); len(remainingSpecs) > 0 {
+		remaining := &specCollection{specs: remainingSpecs}
+		e.aggregateResult.unhandledErrors = append(e.aggregateResult.unhandledErrors, streamExecError{specsSkipped: remaining.specNames(), message: "Failed to start a runner in any of the parallel streams"})
+	}
 	e.aggregateResult.timestamp = startTime.Format(config.LayoutForTimeStamp)
 	e.aggregateResult.projectName = filepath.Base(config.ProjectRoot)
 	e.aggregateResult.environment = env.CurrentEnv
@@ -96,20 +113,28 @@ func (e *parallelSpecExecution) start() *suiteResult {
 	return e.aggregateResult
 }
 
-func (e *parallelSpecExecution) startSpecsExecution(specCollection *specCollection, suiteResults chan *suiteResult, runner *testRunner, writer executionLogger) {
-	var err error
-	runner, err = startRunnerAndMakeConnection(e.manifest, writer)
+func (e *parallelSpecExecution) numberOfStreams() int {
+	if e.numberOfExecutionStreams > len(e.specifications) {
+		return len(e.specifications)
+	}
+	return e.numberOfExecutionStreams
+}
+
+// Each stream starts its own runner and keeps pulling specs off the shared queue until it is empty.
+// Specs are left in the queue for the other streams when the runner fails to start.
+func (e *parallelSpecExecution) startSpecsExecution(specs *specQueue, suiteResults chan *suiteResult, writer executionLogger) {
+	runner, err := startRunnerAndMakeConnection(e.manifest, writer)
 	if err != nil {
 		e.writer.Error("Failed: " + err.Error())
-		e.writer.Debug("Skipping %s specifications", strconv.Itoa(len(specCollection.specs)))
-		suiteResults <- &suiteResult{unhandledErrors: []error{streamExecError{specsSkipped: specCollection.specNames(), message: fmt.Sprintf("Failed to start runner. %s", err.Error())}}}
+		e.writer.Debug("Leaving the specifications to the other streams")
+		suiteResults <- &suiteResult{specResults: make([]*specResult, 0)}
 		return
 	}
-	e.startSpecsExecutionWithRunner(specCollection, suiteResults, runner, writer)
+	e.startSpecsExecutionWithRunner(specs, suiteResults, runner, writer)
 }
 
-func (e *parallelSpecExecution) startSpecsExecutionWithRunner(specCollection *specCollection, suiteResults chan *suiteResult, runner *testRunner, writer executionLogger) {
-	execution := newExecution(e.manifest, specCollection.specs, runner, e.pluginHandler, &parallelInfo{inParallel: false}, writer)
+func (e *parallelSpecExecution) startSpecsExecutionWithRunner(specs *specQueue, suiteResults chan *suiteResult, runner *testRunner, writer executionLogger) {
+	execution := &simpleExecution{manifest: e.manifest, specQueue: specs, runner: runner, pluginHandler: e.pluginHandler, writer: writer, failureThreshold: e.failureThreshold, specParts: e.specParts}
 	result := execution.start()
 	runner.kill(e.writer)
 	suiteResults <- result
@@ -142,6 +167,7 @@ func (e *parallelSpecExecution) aggregateResults(suiteResults []*suiteResult) *s
 	for _, result := range suiteResults {
 		aggregateResult.executionTime += result.executionTime
 		aggregateResult.specsFailedCount += result.specsFailedCount
+		aggregateResult.specsSkippedCount += result.specsSkippedCount
 		aggregateResult.specResults = append(aggregateResult.specResults, result.specResults...)
 		if result.isFailed {
 			aggregateResult.isFailed = true
@@ -159,13 +185,34 @@ func (e *parallelSpecExecution) aggregateResults(suiteResults []*suiteResult) *s
 	return aggregateResult
 }
 
+// Merges the results of the parts of each spec executed across the streams back into one result per spec.
+func (e *parallelSpecExecution) mergeSpecParts() {
+	e.aggregateResult.specResults = e.specParts.mergeResults(e.aggregateResult.specResults)
+	e.aggregateResult.specsFailedCount = 0
+	e.aggregateResult.specsSkippedCount = 0
+	for _, result := range e.aggregateResult.specResults {
+		if result.isFailed {
+			e.aggregateResult.specsFailedCount++
+		}
+		if result.isSkipped {
+			e.aggregateResult.specsSkippedCount++
+		}
+	}
+}
+
 func numberOfCores() int {
 	return runtime.NumCPU()
 }
 
+// The parts of a spec split by --parallel-scenarios share its file name, which is listed once
 func (s *specCollection) specNames() []string {
 	specNames := make([]string, 0)
+	seen := make(map[string]bool)
 	for _, spec := range s.specs {
+		if seen[spec.fileName] {
+			continue
+		}
+		seen[spec.fileName] = true
 		specNames = append(specNames, spec.fileName)
 	}
 	return specNames
@@ -58,6 +58,14 @@ func (s *MySuite) TestDistributionOfSpecsWithMoreNumberOfDistributions(c *C) {
 	c.Assert(len(specCollections), Equals, 0)
 }
 
+func (s *MySuite) TestNumberOfStreamsIsLimitedByNumberOfSpecs(c *C) {
+	e := parallelSpecExecution{specifications: createSpecsList(3), numberOfExecutionStreams: 8}
+	c.Assert(e.numberOfStreams(), Equals, 3)
+
+	e = parallelSpecExecution{specifications: createSpecsList(10), numberOfExecutionStreams: 4}
+	c.Assert(e.numberOfStreams(), Equals, 4)
+}
+
 func createSpecsList(number int) []*specification {
 	specs := make([]*specification, 0)
 	for i := 0; i < number; i++ {
@@ -55,9 +55,10 @@ type pluginDescriptor struct {
 		Linux   []string
 		Darwin  []string
 	}
-	Scope               []string
-	GaugeVersionSupport versionSupport
-	pluginPath          string
+	Scope                []string
+	GaugeVersionSupport  versionSupport
+	SpecialParamResolver bool
+	pluginPath           string
 }
 
 type pluginHandler struct {
@@ -250,7 +251,9 @@ func isPluginAdded(manifest *manifest, descriptor *pluginDescriptor) bool {
 	return false
 }
 
-func startPluginsForExecution(manifest *manifest) (*pluginHandler, []string) {
+// Starts either the plugins which resolve special params, which are needed to parse the specs, or all the others.
+// A plugin without a plugin.json is reported only along with the others.
+func startPluginsForExecution(manifest *manifest, specialParamResolvers bool) (*pluginHandler, []string) {
 	warnings := make([]string, 0)
 	handler := &pluginHandler{}
 	envProperties := make(map[string]string)
@@ -258,7 +261,12 @@ func startPluginsForExecution(manifest *manifest) (*pluginHandler, []string) {
 	for _, pluginId := range manifest.Plugins {
 		pd, err := getPluginDescriptor(pluginId, "")
 		if err != nil {
-			warnings = append(warnings, fmt.Sprintf("Error starting plugin %s. Failed to get plugin.json. %s. To install, run `gauge --install %s`.", pluginId, err.Error(), pluginId))
+			if !specialParamResolvers {
+				warnings = append(warnings, fmt.Sprintf("Error starting plugin %s. Failed to get plugin.json. %s. To install, run `gauge --install %s`.", pluginId, err.Error(), pluginId))
+			}
+			continue
+		}
+		if pd.SpecialParamResolver != specialParamResolvers {
 			continue
 		}
 		compatibilityErr := checkCompatibility(version.CurrentGaugeVersion, &pd.GaugeVersionSupport)
@@ -333,6 +341,21 @@ func (handler *pluginHandler) killPlugin(pluginId string) {
 	handler.removePlugin(pluginId)
 }
 
+// Registers the special param prefixes of plugins which declare themselves as special param resolvers
+func (handler *pluginHandler) registerSpecialParamResolvers() {
+	for _, plugin := range handler.pluginsMap {
+		if !plugin.descriptor.SpecialParamResolver {
+			continue
+		}
+		prefixes, err := registerSpecialParamResolvers(newResolverConnection(plugin.connection))
+		if err != nil {
+			logger.Log.Warning("Failed to get special param prefixes from plugin %s %s. %s", plugin.descriptor.Name, plugin.descriptor.Version, err.Error())
+			continue
+		}
+		logger.Log.Debug("Special param prefixes registered by plugin %s: %v", plugin.descriptor.Name, prefixes)
+	}
+}
+
 func (handler *pluginHandler) gracefullyKillPlugins() {
 	var wg sync.WaitGroup
 	for _, plugin := range handler.pluginsMap {
@@ -356,8 +379,19 @@ func (plugin *plugin) sendMessage(message *gauge_messages.Message) error {
 	return nil
 }
 
-func startPlugins(manifest *manifest) *pluginHandler {
-	pluginHandler, warnings := startPluginsForExecution(manifest)
+// Starts the plugins which resolve special params and registers the prefixes they resolve, before the specs are parsed
+func startSpecialParamResolverPlugins(manifest *manifest) *pluginHandler {
+	pluginHandler, warnings := startPluginsForExecution(manifest, true)
 	handleWarningMessages(warnings)
+	pluginHandler.registerSpecialParamResolvers()
 	return pluginHandler
 }
+
+// Starts the rest of the plugins of the project once the specs are parsed and validated
+func (handler *pluginHandler) startPlugins(manifest *manifest) {
+	pluginHandler, warnings := startPluginsForExecution(manifest, false)
+	handleWarningMessages(warnings)
+	for pluginId, plugin := range pluginHandler.pluginsMap {
+		handler.addPlugin(pluginId, plugin)
+	}
+}
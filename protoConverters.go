@@ -32,6 +32,8 @@ func convertToProtoItem(item item) *gauge_messages.ProtoItem {
 		return convertToProtoCommentItem(item.(*comment))
 	case tableKind:
 		return convertToProtoTableItem(item.(*table))
+	case dataTableKind:
+		return convertToProtoTableItem(&item.(*dataTable).table)
 	case tagKind:
 		return convertToProtoTagItem(item.(*tags))
 	}
@@ -39,14 +41,14 @@ func convertToProtoItem(item item) *gauge_messages.ProtoItem {
 }
 
 func convertToProtoTagItem(tags *tags) *gauge_messages.ProtoItem {
-	return &gauge_messages.ProtoItem{ItemType: gauge_messages.ProtoItem_Tags.Enum(), Tags: convertToProtoTags(tags)}
+	return &gauge_messages.ProtoItem{ItemType: gauge_messages.ProtoItem_Tags.Enum(), Tags: convertToProtoTags(tags), Span: convertToProtoSpan(tags.span)}
 }
 
 func convertToProtoStepItem(step *step) *gauge_messages.ProtoItem {
 	if step.isConcept {
 		return convertToProtoConcept(step)
 	}
-	return &gauge_messages.ProtoItem{ItemType: gauge_messages.ProtoItem_Step.Enum(), Step: convertToProtoStep(step)}
+	return &gauge_messages.ProtoItem{ItemType: gauge_messages.ProtoItem_Step.Enum(), Step: convertToProtoStep(step), Span: convertToProtoSpan(step.span)}
 }
 
 func convertToProtoStepItems(steps []*step) []*gauge_messages.ProtoItem {
@@ -62,25 +64,50 @@ func convertToProtoScenarioItem(scenario *scenario) *gauge_messages.ProtoItem {
 	for _, item := range scenario.items {
 		scenarioItems = append(scenarioItems, convertToProtoItem(item))
 	}
-	protoScenario := newProtoScenario(scenario)
-	return &gauge_messages.ProtoItem{ItemType: gauge_messages.ProtoItem_Scenario.Enum(), Scenario: protoScenario}
+	protoScenario := newProtoScenario(scenario, scenario.heading.value)
+	return &gauge_messages.ProtoItem{ItemType: gauge_messages.ProtoItem_Scenario.Enum(), Scenario: protoScenario, Span: convertToProtoSpan(scenario.heading.span)}
 }
 
 func convertToProtoConcept(concept *step) *gauge_messages.ProtoItem {
 	protoConcept := &gauge_messages.ProtoConcept{ConceptStep: convertToProtoStep(concept), Steps: convertToProtoStepItems(concept.conceptSteps)}
-	protoConceptItem := &gauge_messages.ProtoItem{ItemType: gauge_messages.ProtoItem_Concept.Enum(), Concept: protoConcept}
+	protoConceptItem := &gauge_messages.ProtoItem{ItemType: gauge_messages.ProtoItem_Concept.Enum(), Concept: protoConcept, Span: convertToProtoSpan(concept.span)}
 	return protoConceptItem
 }
 
 func convertToProtoStep(step *step) *gauge_messages.ProtoStep {
-	return &gauge_messages.ProtoStep{ActualText: proto.String(step.lineText), ParsedText: proto.String(step.value), Fragments: makeFragmentsCopy(step.fragments)}
+	return &gauge_messages.ProtoStep{ActualText: proto.String(step.lineText), ParsedText: proto.String(step.value), Fragments: makeFragmentsCopy(step.fragments), Span: convertToProtoSpan(step.span)}
 }
 
 func convertToProtoTags(tags *tags) *gauge_messages.ProtoTags {
-	return &gauge_messages.ProtoTags{Tags: getAllTags(tags)}
+	return &gauge_messages.ProtoTags{Tags: getAllTags(tags), Spans: convertToProtoSpans(tags.valueSpans)}
 
 }
 
+//spans are known only for the elements parsed from a file
+func convertToProtoSpan(span span) *gauge_messages.Span {
+	if span.startLine == 0 {
+		return nil
+	}
+	return newProtoSpan(span)
+}
+
+func convertToProtoSpans(spans []span) []*gauge_messages.Span {
+	protoSpans := make([]*gauge_messages.Span, 0)
+	for _, span := range spans {
+		protoSpans = append(protoSpans, newProtoSpan(span))
+	}
+	return protoSpans
+}
+
+func newProtoSpan(span span) *gauge_messages.Span {
+	return &gauge_messages.Span{
+		Start:     proto.Int32(int32(span.startLine)),
+		End:       proto.Int32(int32(span.endLine)),
+		StartChar: proto.Int32(int32(span.startColumn)),
+		EndChar:   proto.Int32(int32(span.endColumn)),
+	}
+}
+
 func getAllTags(tags *tags) []string {
 	allTags := make([]string, 0)
 	for _, tag := range tags.values {
@@ -108,15 +135,15 @@ func makeFragmentCopy(fragment *gauge_messages.Fragment) *gauge_messages.Fragmen
 func makeParameterCopy(parameter *gauge_messages.Parameter) *gauge_messages.Parameter {
 	switch parameter.GetParameterType() {
 	case gauge_messages.Parameter_Static:
-		return &gauge_messages.Parameter{ParameterType: gauge_messages.Parameter_Static.Enum(), Value: proto.String(parameter.GetValue()), Name: proto.String(parameter.GetName())}
+		return &gauge_messages.Parameter{ParameterType: gauge_messages.Parameter_Static.Enum(), Value: proto.String(parameter.GetValue()), Name: proto.String(parameter.GetName()), Span: parameter.GetSpan()}
 	case gauge_messages.Parameter_Dynamic:
-		return &gauge_messages.Parameter{ParameterType: gauge_messages.Parameter_Dynamic.Enum(), Value: proto.String(parameter.GetValue()), Name: proto.String(parameter.GetName())}
+		return &gauge_messages.Parameter{ParameterType: gauge_messages.Parameter_Dynamic.Enum(), Value: proto.String(parameter.GetValue()), Name: proto.String(parameter.GetName()), Span: parameter.GetSpan()}
 	case gauge_messages.Parameter_Table:
-		return &gauge_messages.Parameter{ParameterType: gauge_messages.Parameter_Table.Enum(), Table: makeTableCopy(parameter.GetTable()), Name: proto.String(parameter.GetName())}
+		return &gauge_messages.Parameter{ParameterType: gauge_messages.Parameter_Table.Enum(), Table: makeTableCopy(parameter.GetTable()), Name: proto.String(parameter.GetName()), Span: parameter.GetSpan()}
 	case gauge_messages.Parameter_Special_String:
-		return &gauge_messages.Parameter{ParameterType: gauge_messages.Parameter_Special_String.Enum(), Value: proto.String(parameter.GetValue()), Name: proto.String(parameter.GetName())}
+		return &gauge_messages.Parameter{ParameterType: gauge_messages.Parameter_Special_String.Enum(), Value: proto.String(parameter.GetValue()), Name: proto.String(parameter.GetName()), Span: parameter.GetSpan()}
 	case gauge_messages.Parameter_Special_Table:
-		return &gauge_messages.Parameter{ParameterType: gauge_messages.Parameter_Special_Table.Enum(), Table: makeTableCopy(parameter.GetTable()), Name: proto.String(parameter.GetName())}
+		return &gauge_messages.Parameter{ParameterType: gauge_messages.Parameter_Special_Table.Enum(), Table: makeTableCopy(parameter.GetTable()), Name: proto.String(parameter.GetName()), Span: parameter.GetSpan()}
 	}
 	return parameter
 }
@@ -135,7 +162,8 @@ func makeTableCopy(table *gauge_messages.ProtoTable) *gauge_messages.ProtoTable
 
 func makeProtoTableRowCopy(tableRow *gauge_messages.ProtoTableRow) *gauge_messages.ProtoTableRow {
 	copiedCells := make([]string, 0)
-	return &gauge_messages.ProtoTableRow{Cells: append(copiedCells, tableRow.GetCells()...)}
+	copiedCellSpans := make([]*gauge_messages.Span, 0)
+	return &gauge_messages.ProtoTableRow{Cells: append(copiedCells, tableRow.GetCells()...), CellSpans: append(copiedCellSpans, tableRow.GetCellSpans()...)}
 }
 
 func convertToProtoSteps(steps []*step) []*gauge_messages.ProtoStep {
@@ -165,24 +193,29 @@ func convertToProtoParameters(args []*stepArg) []*gauge_messages.Parameter {
 func convertToProtoParameter(arg *stepArg) *gauge_messages.Parameter {
 	switch arg.argType {
 	case static:
-		return &gauge_messages.Parameter{ParameterType: gauge_messages.Parameter_Static.Enum(), Value: proto.String(arg.value), Name: proto.String(arg.name)}
+		return &gauge_messages.Parameter{ParameterType: gauge_messages.Parameter_Static.Enum(), Value: proto.String(arg.value), Name: proto.String(arg.name), Span: convertToProtoSpan(arg.span)}
 	case dynamic:
-		return &gauge_messages.Parameter{ParameterType: gauge_messages.Parameter_Dynamic.Enum(), Value: proto.String(arg.value), Name: proto.String(arg.name)}
+		return &gauge_messages.Parameter{ParameterType: gauge_messages.Parameter_Dynamic.Enum(), Value: proto.String(arg.value), Name: proto.String(arg.name), Span: convertToProtoSpan(arg.span)}
 	case tableArg:
-		return &gauge_messages.Parameter{ParameterType: gauge_messages.Parameter_Table.Enum(), Table: convertToProtoTableParam(&arg.table), Name: proto.String(arg.name)}
+		return &gauge_messages.Parameter{ParameterType: gauge_messages.Parameter_Table.Enum(), Table: convertToProtoTableParam(&arg.table), Name: proto.String(arg.name), Span: convertToProtoSpan(arg.span)}
 	case specialString:
-		return &gauge_messages.Parameter{ParameterType: gauge_messages.Parameter_Special_String.Enum(), Value: proto.String(arg.value), Name: proto.String(arg.name)}
+		return &gauge_messages.Parameter{ParameterType: gauge_messages.Parameter_Special_String.Enum(), Value: proto.String(arg.value), Name: proto.String(arg.name), Span: convertToProtoSpan(arg.span)}
 	case specialTable:
-		return &gauge_messages.Parameter{ParameterType: gauge_messages.Parameter_Special_Table.Enum(), Table: convertToProtoTableParam(&arg.table), Name: proto.String(arg.name)}
+		return &gauge_messages.Parameter{ParameterType: gauge_messages.Parameter_Special_Table.Enum(), Table: convertToProtoTableParam(&arg.table), Name: proto.String(arg.name), Span: convertToProtoSpan(arg.span)}
 	}
 	return nil
 }
 
+//cell spans are added only for the tables parsed from a spec file
 func convertToProtoTableParam(table *table) *gauge_messages.ProtoTable {
 	protoTableParam := &gauge_messages.ProtoTable{Rows: make([]*gauge_messages.ProtoTableRow, 0)}
-	protoTableParam.Headers = &gauge_messages.ProtoTableRow{Cells: table.headers}
-	for _, row := range table.getRows() {
-		protoTableParam.Rows = append(protoTableParam.Rows, &gauge_messages.ProtoTableRow{Cells: row})
+	protoTableParam.Headers = &gauge_messages.ProtoTableRow{Cells: table.headers, CellSpans: convertToProtoSpans(table.headerSpans)}
+	for i, row := range table.getRows() {
+		protoRow := &gauge_messages.ProtoTableRow{Cells: row}
+		if table.headerSpans != nil {
+			protoRow.CellSpans = convertToProtoSpans(table.getRowSpans(i))
+		}
+		protoTableParam.Rows = append(protoTableParam.Rows, protoRow)
 	}
 	return protoTableParam
 }
@@ -195,17 +228,18 @@ func addExecutionResult(protoItem *gauge_messages.ProtoItem, protoStepExecutionR
 
 func convertToProtoSuiteResult(suiteResult *suiteResult) *gauge_messages.ProtoSuiteResult {
 	protoSuiteResult := &gauge_messages.ProtoSuiteResult{
-		PreHookFailure:   suiteResult.preSuite,
-		PostHookFailure:  suiteResult.postSuite,
-		Failed:           proto.Bool(suiteResult.isFailed),
-		SpecsFailedCount: proto.Int32(int32(suiteResult.specsFailedCount)),
-		ExecutionTime:    proto.Int64(suiteResult.executionTime),
-		SpecResults:      convertToProtoSpecResult(suiteResult.specResults),
-		SuccessRate:      proto.Float32(getSuccessRate(len(suiteResult.specResults), suiteResult.specsFailedCount)),
-		Environment:      proto.String(suiteResult.environment),
-		Tags:             proto.String(suiteResult.tags),
-		ProjectName:      proto.String(suiteResult.projectName),
-		Timestamp:        proto.String(suiteResult.timestamp),
+		PreHookFailure:    suiteResult.preSuite,
+		PostHookFailure:   suiteResult.postSuite,
+		Failed:            proto.Bool(suiteResult.isFailed),
+		SpecsFailedCount:  proto.Int32(int32(suiteResult.specsFailedCount)),
+		ExecutionTime:     proto.Int64(suiteResult.executionTime),
+		SpecResults:       convertToProtoSpecResult(suiteResult.specResults),
+		SuccessRate:       proto.Float32(getSuccessRate(len(suiteResult.specResults), suiteResult.specsFailedCount)),
+		Environment:       proto.String(suiteResult.environment),
+		Tags:              proto.String(suiteResult.tags),
+		ProjectName:       proto.String(suiteResult.projectName),
+		Timestamp:         proto.String(suiteResult.timestamp),
+		SpecsSkippedCount: proto.Int32(int32(suiteResult.specsSkippedCount)),
 	}
 	return protoSuiteResult
 }
@@ -221,12 +255,17 @@ func convertToProtoSpecResult(specResults []*specResult) []*gauge_messages.Proto
 	protoSpecResults := make([]*gauge_messages.ProtoSpecResult, 0)
 	for _, specResult := range specResults {
 		protoSpecResult := &gauge_messages.ProtoSpecResult{
-			ProtoSpec:           specResult.protoSpec,
-			ScenarioCount:       proto.Int32(int32(specResult.scenarioCount)),
-			ScenarioFailedCount: proto.Int32(int32(specResult.scenarioFailedCount)),
-			Failed:              proto.Bool(specResult.isFailed),
-			FailedDataTableRows: specResult.failedDataTableRows,
-			ExecutionTime:       proto.Int64(specResult.executionTime),
+			ProtoSpec:            specResult.protoSpec,
+			ScenarioCount:        proto.Int32(int32(specResult.scenarioCount)),
+			ScenarioFailedCount:  proto.Int32(int32(specResult.scenarioFailedCount)),
+			Failed:               proto.Bool(specResult.isFailed),
+			FailedDataTableRows:  specResult.failedDataTableRows,
+			ExecutionTime:        proto.Int64(specResult.executionTime),
+			Skipped:              proto.Bool(specResult.isSkipped),
+			ScenarioSkippedCount: proto.Int32(int32(specResult.scenarioSkippedCount)),
+		}
+		if specResult.isSkipped {
+			protoSpecResult.SkipReason = proto.String(specResult.skipReason)
 		}
 		protoSpecResults = append(protoSpecResults, protoSpecResult)
 	}
@@ -237,7 +276,9 @@ func convertToProtoSpec(spec *specification) *gauge_messages.ProtoSpec {
 	protoSpec := newProtoSpec(spec)
 	protoItems := make([]*gauge_messages.ProtoItem, 0)
 	for _, item := range spec.items {
-		protoItems = append(protoItems, convertToProtoItem(item))
+		if protoItem := convertToProtoItem(item); protoItem != nil {
+			protoItems = append(protoItems, protoItem)
+		}
 	}
 	protoSpec.Items = protoItems
 	return protoSpec
@@ -266,18 +307,37 @@ func newProtoSpec(specification *specification) *gauge_messages.ProtoSpec {
 		IsTableDriven: proto.Bool(false),
 		FileName:      proto.String(specification.fileName),
 		Tags:          getTags(specification.tags),
+		HeadingSpan:   convertToProtoSpan(specification.heading.span),
+		Metadata:      convertToProtoMetadata(specification.metadata),
 	}
 
 }
 
-func newProtoScenario(scenario *scenario) *gauge_messages.ProtoScenario {
-	return &gauge_messages.ProtoScenario{
-		ScenarioHeading: proto.String(scenario.heading.value),
+// The heading is passed separately, as the data table parameters in it are resolved for every row being executed
+func newProtoScenario(scenario *scenario, heading string) *gauge_messages.ProtoScenario {
+	protoScenario := &gauge_messages.ProtoScenario{
+		ScenarioHeading: proto.String(heading),
 		Failed:          proto.Bool(false),
 		Tags:            getTags(scenario.tags),
 		Contexts:        make([]*gauge_messages.ProtoItem, 0),
 		ExecutionTime:   proto.Int64(0),
 	}
+	if scenario.skip != nil {
+		protoScenario.Skipped = proto.Bool(true)
+		protoScenario.SkipReason = proto.String(scenario.skip.reason)
+	}
+	return protoScenario
+}
+
+func convertToProtoMetadata(metadata *metadata) []*gauge_messages.MetadataEntry {
+	protoMetadata := make([]*gauge_messages.MetadataEntry, 0)
+	if metadata == nil {
+		return protoMetadata
+	}
+	for _, entry := range metadata.entries {
+		protoMetadata = append(protoMetadata, &gauge_messages.MetadataEntry{Key: proto.String(entry.key), Value: proto.String(entry.value)})
+	}
+	return protoMetadata
 }
 
 func getTags(tags *tags) []string {
@@ -108,3 +108,58 @@ func compareTable(table1 *gauge_messages.ProtoTable, table2 *gauge_messages.Prot
 func compareTableRow(row1 *gauge_messages.ProtoTableRow, row2 *gauge_messages.ProtoTableRow, c *C) {
 	c.Assert(row1.GetCells(), DeepEquals, row2.GetCells())
 }
+
+func (s *MySuite) TestTextBlockArgumentRoundTripsThroughProtoParameter(c *C) {
+	textBlock := &stepArg{argType: specialString, value: "select *\nfrom users", name: "```sql"}
+
+	parameter := convertToProtoParameter(textBlock)
+	c.Assert(parameter.GetParameterType(), Equals, gauge_messages.Parameter_Special_String)
+	c.Assert(parameter.GetValue(), Equals, "select *\nfrom users")
+
+	args := createStepArgsFromProtoArguments([]*gauge_messages.Parameter{parameter})
+	c.Assert(args[0], DeepEquals, textBlock)
+	c.Assert(formatTextBlock(args[0].name, args[0].value), Equals, "```sql\nselect *\nfrom users\n```\n")
+}
+
+func (s *MySuite) TestConvertingSpansToProto(c *C) {
+	specText := "# Spec heading\ntags: foo\n## Scenario\n* step \"arg\"\n   |id|\n   |1|\n"
+	spec, result := new(specParser).parse(specText, new(conceptDictionary))
+	c.Assert(result.ok, Equals, true)
+
+	protoSpec := convertToProtoSpec(spec)
+
+	c.Assert(protoSpec.GetHeadingSpan().GetStart(), Equals, int32(1))
+	c.Assert(protoSpec.GetItems()[0].GetTags().GetSpans()[0].GetStartChar(), Equals, int32(7))
+	protoScenario := protoSpec.GetItems()[1]
+	c.Assert(protoScenario.GetSpan().GetStart(), Equals, int32(3))
+	protoStep := convertToProtoStepItem(spec.scenarios[0].steps[0])
+	c.Assert(protoStep.GetSpan().GetStart(), Equals, int32(4))
+	c.Assert(protoStep.GetStep().GetSpan().GetEndChar(), Equals, int32(12))
+	staticParam := protoStep.GetStep().GetFragments()[1].GetParameter()
+	c.Assert(staticParam.GetSpan().GetStartChar(), Equals, int32(8))
+	c.Assert(staticParam.GetSpan().GetEndChar(), Equals, int32(12))
+	tableParam := protoStep.GetStep().GetFragments()[3].GetParameter()
+	c.Assert(tableParam.GetSpan().GetEnd(), Equals, int32(6))
+	c.Assert(tableParam.GetTable().GetRows()[0].GetCellSpans()[0].GetStartChar(), Equals, int32(5))
+}
+
+func (s *MySuite) TestConvertingMetadataToProto(c *C) {
+	specText := "---\nowner: qa-team\njira: GAUGE-123\n---\n# Spec heading\n## Scenario\n* step\n"
+	spec, result := new(specParser).parse(specText, new(conceptDictionary))
+	c.Assert(result.ok, Equals, true)
+
+	protoSpec := convertToProtoSpec(spec)
+
+	c.Assert(len(protoSpec.GetMetadata()), Equals, 2)
+	c.Assert(protoSpec.GetMetadata()[0].GetKey(), Equals, "owner")
+	c.Assert(protoSpec.GetMetadata()[0].GetValue(), Equals, "qa-team")
+	c.Assert(protoSpec.GetMetadata()[1].GetKey(), Equals, "jira")
+	c.Assert(len(protoSpec.GetItems()), Equals, 1)
+	c.Assert(len(convertToProtoMetadata(nil)), Equals, 0)
+}
+
+func (s *MySuite) TestSpanIsNotAddedForStepsNotFromAFile(c *C) {
+	protoStep := convertToProtoStep(&step{value: "step", lineText: "step"})
+
+	c.Assert(protoStep.GetSpan(), IsNil)
+}
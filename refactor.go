@@ -71,9 +71,9 @@ func performRephraseRefactoring(oldStep, newStep string) *refactoringResult {
 	if !result.success {
 		return result
 	}
-	conceptDictionary, parseResult := createConceptsDictionary(false)
+	conceptDictionary, parseResults := createConceptsDictionary(false)
 
-	addErrorsAndWarningsToRefactoringResult(result, parseResult)
+	addErrorsAndWarningsToRefactoringResult(result, parseResults...)
 	if !result.success {
 		return result
 	}
@@ -171,9 +171,9 @@ func SliceIndex(limit int, predicate func(i int) bool) int {
 
 func getRefactorAgent(oldStepText, newStepText string) (*rephraseRefactorer, error) {
 	parser := new(specParser)
-	stepTokens, err := parser.generateTokens("* " + oldStepText + "\n" + "*" + newStepText)
-	if err != nil {
-		return nil, err
+	stepTokens, errs := parser.generateTokens("* " + oldStepText + "\n" + "*" + newStepText)
+	if len(errs) > 0 {
+		return nil, errs[0]
 	}
 	spec := &specification{}
 	steps := make([]*step, 0)
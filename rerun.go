@@ -0,0 +1,260 @@
+// Copyright 2015 ThoughtWorks, Inc.
+
+// This file is part of Gauge.
+
+// Gauge is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+
+// Gauge is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+
+// You should have received a copy of the GNU General Public License
+// along with Gauge.  If not, see <http://www.gnu.org/licenses/>.
+
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/getgauge/common"
+	"github.com/getgauge/gauge/config"
+	"github.com/getgauge/gauge/gauge_messages"
+	"github.com/getgauge/gauge/logger"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+const (
+	dotGaugeDirectoryName = ".gauge"
+	failuresFileName      = "failures.json"
+)
+
+// failedSpec records a spec that failed in the last run. Scenarios holds the
+// headings of the failed scenarios; it is empty when the spec failed as a
+// whole (eg: a before spec hook failure), in which case the entire spec is rerun.
+type failedSpec struct {
+	FileName  string   `json:"fileName"`
+	Scenarios []string `json:"scenarios,omitempty"`
+}
+
+func getFailuresFilePath() string {
+	return filepath.Join(config.ProjectRoot, dotGaugeDirectoryName, failuresFileName)
+}
+
+// The failures of specs which did not run, eg: specs outside the spec sources of a scoped run, are kept as they were
+func saveFailedScenarios(result *suiteResult) {
+	failuresFile := getFailuresFilePath()
+	previousFailedSpecs := make([]*failedSpec, 0)
+	if common.FileExists(failuresFile) {
+		failedSpecs, err := readFailedSpecs(failuresFile)
+		if err != nil {
+			logger.Log.Warning("Discarding the failures of the previous runs. %s\n", err.Error())
+		} else {
+			previousFailedSpecs = failedSpecs
+		}
+	}
+	if err := writeFailedSpecs(failuresFile, mergeFailedSpecs(previousFailedSpecs, result)); err != nil {
+		logger.Log.Warning("Failed to save the failed scenarios. %s\n", err.Error())
+	}
+}
+
+// Replaces the previous failures of the specs that were executed with their failures in the given result
+func mergeFailedSpecs(previousFailedSpecs []*failedSpec, result *suiteResult) []*failedSpec {
+	executedSpecs := make(map[string]bool)
+	for _, specResult := range result.specResults {
+		if !specResult.isSkipped {
+			executedSpecs[relativeToProjectRoot(specResult.protoSpec.GetFileName())] = true
+		}
+	}
+	failedSpecs := make([]*failedSpec, 0)
+	for _, failedSpec := range previousFailedSpecs {
+		if !executedSpecs[failedSpec.FileName] {
+			failedSpecs = append(failedSpecs, failedSpec)
+		}
+	}
+	return append(failedSpecs, getFailedSpecs(result)...)
+}
+
+func writeFailedSpecs(failuresFile string, failedSpecs []*failedSpec) error {
+	contents, err := json.MarshalIndent(failedSpecs, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(failuresFile), common.NewDirectoryPermissions); err != nil {
+		return err
+	}
+	return ioutil.WriteFile(failuresFile, contents, common.NewFilePermissions)
+}
+
+func readFailedSpecs(failuresFile string) ([]*failedSpec, error) {
+	if !common.FileExists(failuresFile) {
+		return nil, errors.New(fmt.Sprintf("%s not found. Run the specs at least once before using --failed", failuresFile))
+	}
+	contents, err := common.ReadFileContents(failuresFile)
+	if err != nil {
+		return nil, err
+	}
+	failedSpecs := make([]*failedSpec, 0)
+	if err := json.Unmarshal([]byte(contents), &failedSpecs); err != nil {
+		return nil, errors.New(fmt.Sprintf("Failed to read %s. %s", failuresFile, err.Error()))
+	}
+	return failedSpecs, nil
+}
+
+func getFailedSpecs(result *suiteResult) []*failedSpec {
+	failedSpecs := make([]*failedSpec, 0)
+	for _, specResult := range result.specResults {
+		if !specResult.isFailed {
+			continue
+		}
+		failedSpecs = append(failedSpecs, &failedSpec{FileName: relativeToProjectRoot(specResult.protoSpec.GetFileName()), Scenarios: getFailedScenarioHeadings(specResult.protoSpec)})
+	}
+	return failedSpecs
+}
+
+func getFailedScenarioHeadings(protoSpec *gauge_messages.ProtoSpec) []string {
+	headings := make([]string, 0)
+	for _, item := range protoSpec.GetItems() {
+		switch item.GetItemType() {
+		case gauge_messages.ProtoItem_Scenario:
+			if item.GetScenario().GetFailed() {
+				headings = append(headings, item.GetScenario().GetScenarioHeading())
+			}
+		case gauge_messages.ProtoItem_TableDrivenScenario:
+			for _, scenario := range item.GetTableDrivenScenario().GetScenarios() {
+				if scenario.GetFailed() {
+					headings = append(headings, scenario.GetScenarioHeading())
+					break
+				}
+			}
+		}
+	}
+	return headings
+}
+
+func relativeToProjectRoot(fileName string) string {
+	relPath, err := filepath.Rel(config.ProjectRoot, fileName)
+	if err != nil {
+		return fileName
+	}
+	return relPath
+}
+
+// Only the failures of the specs under the given spec sources are rerun. All the failures are rerun when no spec sources are given.
+func specsFromFailures(specSources []string, conceptDictionary *conceptDictionary) ([]*specification, []*parseResult) {
+	failedSpecs, err := readFailedSpecs(getFailuresFilePath())
+	if err != nil {
+		handleCriticalError(err)
+	}
+	specs := make([]*specification, 0)
+	allParseResults := make([]*parseResult, 0)
+	for _, failedSpec := range failedSpecs {
+		specFile := failedSpec.FileName
+		if !filepath.IsAbs(specFile) {
+			specFile = filepath.Join(config.ProjectRoot, specFile)
+		}
+		if !common.FileExists(specFile) {
+			logger.Log.Warning("Skipping %s. The spec file no longer exists.\n", failedSpec.FileName)
+			continue
+		}
+		if !isUnderSpecSources(specFile, specSources) {
+			continue
+		}
+		parsedSpecs, parseResults := parseSpecFiles([]string{specFile}, conceptDictionary)
+		allParseResults = append(allParseResults, parseResults...)
+		specs = append(specs, retainFailedScenarios(parsedSpecs, failedSpec.Scenarios)...)
+	}
+	return specs, allParseResults
+}
+
+func isUnderSpecSources(specFile string, specSources []string) bool {
+	if len(specSources) == 0 {
+		return true
+	}
+	for _, specSource := range specSources {
+		if isTableRowsSpec(specSource) {
+			specSource, _ = getTableRowsSpec(specSource)
+		} else {
+			specSource = getSpecName(specSource)
+		}
+		sourcePath, err := filepath.Abs(specSource)
+		if err != nil {
+			continue
+		}
+		relPath, err := filepath.Rel(sourcePath, specFile)
+		if err == nil && relPath != ".." && !strings.HasPrefix(relPath, ".."+string(filepath.Separator)) {
+			return true
+		}
+	}
+	return false
+}
+
+func retainFailedScenarios(specs []*specification, failedScenarios []string) []*specification {
+	if len(failedScenarios) == 0 {
+		return specs
+	}
+	filteredSpecs := make([]*specification, 0)
+	for _, spec := range specs {
+		indexes := make([]int, 0)
+		for index, scenario := range spec.scenarios {
+			if containsAnyHeading(failedScenarios, resolvedHeadings(spec, scenario)) {
+				indexes = append(indexes, index)
+			}
+		}
+		filteredSpecs = append(filteredSpecs, filterSpecsItems([]*specification{spec}, newScenarioIndexFilterToRetain(indexes...))...)
+	}
+	return filteredSpecs
+}
+
+func containsHeading(headings []string, heading string) bool {
+	for _, h := range headings {
+		if h == heading {
+			return true
+		}
+	}
+	return false
+}
+
+func containsAnyHeading(headings []string, resolvedHeadings []string) bool {
+	for _, heading := range resolvedHeadings {
+		if containsHeading(headings, heading) {
+			return true
+		}
+	}
+	return false
+}
+
+// Failures are recorded with the data table parameters of the headings resolved, so the heading of a
+// scenario is resolved with every row of the spec and scenario data tables to match them.
+func resolvedHeadings(spec *specification, scenario *scenario) []string {
+	headings := make([]string, 0)
+	specRows := rowCountOrOne(&spec.dataTable.table)
+	scenarioRows := rowCountOrOne(&scenario.dataTable.table)
+	for specRow := 0; specRow < specRows; specRow++ {
+		for scenarioRow := 0; scenarioRow < scenarioRows; scenarioRow++ {
+			lookup := new(argLookup)
+			if spec.dataTable.table.getRowCount() > 0 {
+				lookup.addDataTableRow(&spec.dataTable.table, specRow)
+			}
+			if scenario.isTableDriven() {
+				lookup.addDataTableRow(&scenario.dataTable.table, scenarioRow)
+			}
+			headings = append(headings, scenario.resolveHeading(lookup))
+		}
+	}
+	return headings
+}
+
+func rowCountOrOne(table *table) int {
+	if table.getRowCount() == 0 {
+		return 1
+	}
+	return table.getRowCount()
+}
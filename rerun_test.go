@@ -0,0 +1,190 @@
+// Copyright 2015 ThoughtWorks, Inc.
+
+// This file is part of Gauge.
+
+// Gauge is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+
+// Gauge is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+
+// You should have received a copy of the GNU General Public License
+// along with Gauge.  If not, see <http://www.gnu.org/licenses/>.
+
+package main
+
+import (
+	"github.com/getgauge/gauge/config"
+	"github.com/getgauge/gauge/gauge_messages"
+	"github.com/golang/protobuf/proto"
+	. "gopkg.in/check.v1"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+)
+
+func (s *MySuite) TestGetFailedSpecsRecordsFailedScenarioHeadings(c *C) {
+	passedScenario := &gauge_messages.ProtoScenario{ScenarioHeading: proto.String("passing"), Failed: proto.Bool(false)}
+	failedScenario := &gauge_messages.ProtoScenario{ScenarioHeading: proto.String("failing"), Failed: proto.Bool(true)}
+	failedSpecResult := &specResult{protoSpec: &gauge_messages.ProtoSpec{FileName: proto.String("foo.spec")}}
+	failedSpecResult.addScenarioResults([]*scenarioResult{&scenarioResult{passedScenario}, &scenarioResult{failedScenario}})
+	passedSpecResult := &specResult{protoSpec: &gauge_messages.ProtoSpec{FileName: proto.String("bar.spec")}}
+	passedSpecResult.addScenarioResults([]*scenarioResult{&scenarioResult{passedScenario}})
+	result := newSuiteResult()
+	result.addSpecResult(failedSpecResult)
+	result.addSpecResult(passedSpecResult)
+
+	failedSpecs := getFailedSpecs(result)
+
+	c.Assert(len(failedSpecs), Equals, 1)
+	c.Assert(failedSpecs[0].FileName, Equals, "foo.spec")
+	c.Assert(failedSpecs[0].Scenarios, DeepEquals, []string{"failing"})
+}
+
+func (s *MySuite) TestFailedSpecsAreWrittenAndReadBack(c *C) {
+	dir, err := ioutil.TempDir("", "gauge_failures")
+	c.Assert(err, IsNil)
+	defer os.RemoveAll(dir)
+	failuresFile := filepath.Join(dir, dotGaugeDirectoryName, failuresFileName)
+
+	err = writeFailedSpecs(failuresFile, []*failedSpec{&failedSpec{FileName: "specs/foo.spec", Scenarios: []string{"first", "third"}}})
+	c.Assert(err, IsNil)
+	failedSpecs, err := readFailedSpecs(failuresFile)
+
+	c.Assert(err, IsNil)
+	c.Assert(len(failedSpecs), Equals, 1)
+	c.Assert(failedSpecs[0].FileName, Equals, "specs/foo.spec")
+	c.Assert(failedSpecs[0].Scenarios, DeepEquals, []string{"first", "third"})
+}
+
+func (s *MySuite) TestMergeFailedSpecsKeepsFailuresOfSpecsWhichWereNotRun(c *C) {
+	previousFailedSpecs := []*failedSpec{&failedSpec{FileName: "foo.spec", Scenarios: []string{"first"}},
+		&failedSpec{FileName: "bar.spec", Scenarios: []string{"second"}}, &failedSpec{FileName: "baz.spec"}}
+	failedScenario := &gauge_messages.ProtoScenario{ScenarioHeading: proto.String("third"), Failed: proto.Bool(true)}
+	passedScenario := &gauge_messages.ProtoScenario{ScenarioHeading: proto.String("second"), Failed: proto.Bool(false)}
+	fooResult := &specResult{protoSpec: &gauge_messages.ProtoSpec{FileName: proto.String("foo.spec")}}
+	fooResult.addScenarioResults([]*scenarioResult{&scenarioResult{failedScenario}})
+	barResult := &specResult{protoSpec: &gauge_messages.ProtoSpec{FileName: proto.String("bar.spec")}}
+	barResult.addScenarioResults([]*scenarioResult{&scenarioResult{passedScenario}})
+	result := newSuiteResult()
+	result.addSpecResult(fooResult)
+	result.addSpecResult(barResult)
+
+	failedSpecs := mergeFailedSpecs(previousFailedSpecs, result)
+
+	c.Assert(len(failedSpecs), Equals, 2)
+	c.Assert(failedSpecs[0].FileName, Equals, "baz.spec")
+	c.Assert(failedSpecs[1].FileName, Equals, "foo.spec")
+	c.Assert(failedSpecs[1].Scenarios, DeepEquals, []string{"third"})
+}
+
+func (s *MySuite) TestScopedRerunKeepsRecordedFailuresOfOtherSpecs(c *C) {
+	projectRoot, err := ioutil.TempDir("", "gauge_failures")
+	c.Assert(err, IsNil)
+	defer os.RemoveAll(projectRoot)
+	oldProjectRoot := config.ProjectRoot
+	config.ProjectRoot = projectRoot
+	defer func() { config.ProjectRoot = oldProjectRoot }()
+	fooSpec, barSpec := filepath.Join("specs", "foo.spec"), filepath.Join("specs", "bar.spec")
+	err = writeFailedSpecs(getFailuresFilePath(), []*failedSpec{&failedSpec{FileName: fooSpec, Scenarios: []string{"first"}}, &failedSpec{FileName: barSpec}})
+	c.Assert(err, IsNil)
+	rerunResult := &specResult{protoSpec: &gauge_messages.ProtoSpec{FileName: proto.String(filepath.Join(projectRoot, fooSpec))}}
+	rerunResult.addScenarioResults([]*scenarioResult{&scenarioResult{&gauge_messages.ProtoScenario{ScenarioHeading: proto.String("first"), Failed: proto.Bool(false)}}})
+	result := newSuiteResult()
+	result.addSpecResult(rerunResult)
+
+	saveFailedScenarios(result)
+
+	failedSpecs, err := readFailedSpecs(getFailuresFilePath())
+	c.Assert(err, IsNil)
+	c.Assert(len(failedSpecs), Equals, 1)
+	c.Assert(failedSpecs[0].FileName, Equals, barSpec)
+}
+
+func (s *MySuite) TestRetainFailedScenarios(c *C) {
+	specText := SpecBuilder().specHeading("spec heading").
+		scenarioHeading("First scenario").
+		step("a step").
+		scenarioHeading("Second scenario").
+		step("second step").
+		scenarioHeading("Third scenario").
+		step("third step").String()
+	spec, parseResult := new(specParser).parse(specText, new(conceptDictionary))
+	c.Assert(parseResult.ok, Equals, true)
+
+	specs := retainFailedScenarios([]*specification{spec}, []string{"Third scenario", "First scenario"})
+
+	c.Assert(len(specs), Equals, 1)
+	c.Assert(len(specs[0].scenarios), Equals, 2)
+	c.Assert(specs[0].scenarios[0].heading.value, Equals, "First scenario")
+	c.Assert(specs[0].scenarios[1].heading.value, Equals, "Third scenario")
+}
+
+func (s *MySuite) TestRetainFailedScenariosRetainsWholeSpecWhenNoScenariosRecorded(c *C) {
+	specText := SpecBuilder().specHeading("spec heading").
+		scenarioHeading("First scenario").
+		step("a step").
+		scenarioHeading("Second scenario").
+		step("second step").String()
+	spec, parseResult := new(specParser).parse(specText, new(conceptDictionary))
+	c.Assert(parseResult.ok, Equals, true)
+
+	specs := retainFailedScenarios([]*specification{spec}, []string{})
+
+	c.Assert(len(specs), Equals, 1)
+	c.Assert(len(specs[0].scenarios), Equals, 2)
+}
+
+func (s *MySuite) TestRetainFailedScenariosMatchesHeadingsWithDataTableParameters(c *C) {
+	specText := SpecBuilder().specHeading("spec heading").
+		tableHeader("user").
+		tableRow("john").
+		scenarioHeading("Login as <user>").
+		step("a step").
+		scenarioHeading("Logout").
+		step("second step").String()
+	spec, parseResult := new(specParser).parse(specText, new(conceptDictionary))
+	c.Assert(parseResult.ok, Equals, true)
+
+	specs := retainFailedScenarios([]*specification{spec}, []string{"Login as john"})
+
+	c.Assert(len(specs[0].scenarios), Equals, 1)
+	c.Assert(specs[0].scenarios[0].heading.value, Equals, "Login as <user>")
+}
+
+func (s *MySuite) TestRetainFailedScenariosMatchesOnlyHeadingsResolvedFromDataTableRows(c *C) {
+	specText := SpecBuilder().specHeading("spec heading").
+		scenarioHeading("Login <user>").
+		tableHeader("user").
+		tableRow("john").
+		tableRow("admin").
+		step("a step <user>").
+		scenarioHeading("Login admin fails").
+		step("second step").String()
+	spec, parseResult := new(specParser).parse(specText, new(conceptDictionary))
+	c.Assert(parseResult.ok, Equals, true)
+
+	specs := retainFailedScenarios([]*specification{spec}, []string{"Login admin fails"})
+	c.Assert(len(specs[0].scenarios), Equals, 1)
+	c.Assert(specs[0].scenarios[0].heading.value, Equals, "Login admin fails")
+
+	spec, _ = new(specParser).parse(specText, new(conceptDictionary))
+	specs = retainFailedScenarios([]*specification{spec}, []string{"Login admin"})
+	c.Assert(len(specs[0].scenarios), Equals, 1)
+	c.Assert(specs[0].scenarios[0].heading.value, Equals, "Login <user>")
+}
+
+func (s *MySuite) TestIsUnderSpecSources(c *C) {
+	specFile := filepath.Join(os.TempDir(), "specs", "login", "foo.spec")
+
+	c.Assert(isUnderSpecSources(specFile, []string{}), Equals, true)
+	c.Assert(isUnderSpecSources(specFile, []string{filepath.Join(os.TempDir(), "specs")}), Equals, true)
+	c.Assert(isUnderSpecSources(specFile, []string{specFile + ":2"}), Equals, true)
+	c.Assert(isUnderSpecSources(specFile, []string{specFile + ":rows=1,3"}), Equals, true)
+	c.Assert(isUnderSpecSources(specFile, []string{filepath.Join(os.TempDir(), "specs", "logout")}), Equals, false)
+	c.Assert(isUnderSpecSources(specFile, []string{filepath.Join(os.TempDir(), "specs", "login", "bar.spec")}), Equals, false)
+}
@@ -18,31 +18,77 @@
 package main
 
 import (
+	"bytes"
 	"encoding/csv"
+	"encoding/json"
+	"errors"
 	"fmt"
 	"github.com/getgauge/common"
+	"github.com/getgauge/gauge/config"
+	"github.com/getgauge/gauge/conn"
 	"github.com/getgauge/gauge/gauge_messages"
+	"github.com/getgauge/gauge/logger"
 	"github.com/golang/protobuf/proto"
+	"gopkg.in/yaml.v2"
+	"net"
+	"path/filepath"
 	"regexp"
 	"strings"
+	"sync"
 )
 
 type invalidSpecialParamError struct {
 	message string
 }
 
+type specialParamOutsideProjectError struct {
+	message string
+}
+
 type resolverFn func(string) (*stepArg, error)
 type specialTypeResolver struct {
 	predefinedResolvers map[string]resolverFn
+	registeredResolvers *specialParamRegistry
+}
+
+// Resolvers for special param prefixes registered by the runner or plugins. Specs are parsed concurrently,
+// so the resolvers are looked up under a lock.
+type specialParamRegistry struct {
+	resolvers map[string]resolverFn
+	mutex     sync.RWMutex
+}
+
+var registeredResolvers = &specialParamRegistry{resolvers: make(map[string]resolverFn)}
+
+// A connection to the runner or a plugin which resolves special params. Responses are not matched to the
+// requests they answer, so only one request is sent over the connection at a time.
+type resolverConnection struct {
+	//returns the current connection, as a restarted runner is connected afresh
+	connection func() net.Conn
+	mutex      sync.Mutex
+}
+
+func newResolverConnection(connection net.Conn) *resolverConnection {
+	return &resolverConnection{connection: func() net.Conn { return connection }}
 }
 
 type paramResolver struct {
 }
 
+// An object of a json or yaml list, which becomes a row of a table
+type tableRecord struct {
+	keys   []string
+	values map[string]string
+}
+
 func (invalidSpecialParamError invalidSpecialParamError) Error() string {
 	return invalidSpecialParamError.message
 }
 
+func (outsideProjectError specialParamOutsideProjectError) Error() string {
+	return outsideProjectError.message
+}
+
 func (paramResolver *paramResolver) getResolvedParams(step *step, parent *step, dataTableLookup *argLookup) []*gauge_messages.Parameter {
 	parameters := make([]*gauge_messages.Parameter, 0)
 	for _, arg := range step.args {
@@ -59,7 +105,7 @@ func (paramResolver *paramResolver) getResolvedParams(step *step, parent *step,
 				resolvedArg = dataTableLookup.getArg(arg.value)
 			}
 			//In case a special table used in a concept, you will get a dynamic table value which has to be resolved from the concept lookup
-			parameter.Name = proto.String(resolvedArg.name)
+			parameter.Name = proto.String(resolvedArg.resolvedName())
 			if resolvedArg.table.isInitialized() {
 				parameter.ParameterType = gauge_messages.Parameter_Special_Table.Enum()
 				parameter.Table = paramResolver.createProtoStepTable(&resolvedArg.table, dataTableLookup)
@@ -68,9 +114,11 @@ func (paramResolver *paramResolver) getResolvedParams(step *step, parent *step,
 				parameter.Value = proto.String(resolvedArg.value)
 			}
 		} else if arg.argType == specialString {
+			parameter.Name = proto.String(arg.resolvedName())
 			parameter.ParameterType = gauge_messages.Parameter_Special_String.Enum()
 			parameter.Value = proto.String(arg.value)
 		} else if arg.argType == specialTable {
+			parameter.Name = proto.String(arg.resolvedName())
 			parameter.ParameterType = gauge_messages.Parameter_Special_Table.Enum()
 			parameter.Table = paramResolver.createProtoStepTable(&arg.table, dataTableLookup)
 		} else {
@@ -107,22 +155,115 @@ func (resolver *paramResolver) createProtoStepTable(table *table, dataTableLooku
 }
 
 func newSpecialTypeResolver() *specialTypeResolver {
+	return newSpecialTypeResolverFor("")
+}
+
+// Resolver for special params used in the given spec or concept file, relative file paths are resolved against it
+func newSpecialTypeResolverFor(sourceFile string) *specialTypeResolver {
 	resolver := new(specialTypeResolver)
-	resolver.predefinedResolvers = initializePredefinedResolvers()
+	resolver.predefinedResolvers = initializePredefinedResolvers(sourceFile)
+	resolver.registeredResolvers = registeredResolvers
 	return resolver
 }
 
-func initializePredefinedResolvers() map[string]resolverFn {
+func registerSpecialParamResolver(prefix string, resolve resolverFn) error {
+	if _, found := initializePredefinedResolvers("")[prefix]; found {
+		return errors.New(fmt.Sprintf("Special param prefix '%s' is predefined and cannot be registered", prefix))
+	}
+	registeredResolvers.add(prefix, resolve)
+	return nil
+}
+
+func (registry *specialParamRegistry) add(prefix string, resolve resolverFn) {
+	registry.mutex.Lock()
+	defer registry.mutex.Unlock()
+	registry.resolvers[prefix] = resolve
+}
+
+func (registry *specialParamRegistry) remove(prefix string) {
+	registry.mutex.Lock()
+	defer registry.mutex.Unlock()
+	delete(registry.resolvers, prefix)
+}
+
+func (registry *specialParamRegistry) get(prefix string) (resolverFn, bool) {
+	registry.mutex.RLock()
+	defer registry.mutex.RUnlock()
+	resolve, found := registry.resolvers[prefix]
+	return resolve, found
+}
+
+func (resolverConnection *resolverConnection) getResponse(message *gauge_messages.Message) (*gauge_messages.Message, error) {
+	resolverConnection.mutex.Lock()
+	defer resolverConnection.mutex.Unlock()
+	return conn.GetResponseForGaugeMessage(message, resolverConnection.connection())
+}
+
+// Asks the runner or plugin at the other end of the connection for the special param prefixes it resolves
+// and registers a resolver for each of them. Only runners and plugins which declare themselves as special param
+// resolvers are asked, so the request waits for the response like the other requests sent to them.
+func registerSpecialParamResolvers(resolverConnection *resolverConnection) ([]string, error) {
+	message := &gauge_messages.Message{MessageType: gauge_messages.Message_SpecialParamPrefixesRequest.Enum(),
+		SpecialParamPrefixesRequest: &gauge_messages.SpecialParamPrefixesRequest{}}
+	response, err := resolverConnection.getResponse(message)
+	if err != nil {
+		return nil, err
+	}
+	if response.GetMessageType() != gauge_messages.Message_SpecialParamPrefixesResponse {
+		return nil, errors.New(fmt.Sprintf("Expected %s but got %s", gauge_messages.Message_SpecialParamPrefixesResponse, response.GetMessageType()))
+	}
+	prefixes := make([]string, 0)
+	for _, prefix := range response.GetSpecialParamPrefixesResponse().GetPrefixes() {
+		prefix = strings.TrimSpace(prefix)
+		if err := registerSpecialParamResolver(prefix, connectionResolver(prefix, resolverConnection)); err != nil {
+			logger.Log.Warning(err.Error())
+			continue
+		}
+		prefixes = append(prefixes, prefix)
+	}
+	return prefixes, nil
+}
+
+func connectionResolver(prefix string, resolverConnection *resolverConnection) resolverFn {
+	return func(value string) (*stepArg, error) {
+		message := &gauge_messages.Message{MessageType: gauge_messages.Message_ResolveSpecialParamRequest.Enum(),
+			ResolveSpecialParamRequest: &gauge_messages.ResolveSpecialParamRequest{Prefix: proto.String(prefix), Value: proto.String(value)}}
+		response, err := resolverConnection.getResponse(message)
+		if err != nil {
+			return nil, err
+		}
+		resolveResponse := response.GetResolveSpecialParamResponse()
+		if resolveResponse == nil {
+			return nil, errors.New(fmt.Sprintf("Expected %s but got %s", gauge_messages.Message_ResolveSpecialParamResponse, response.GetMessageType()))
+		}
+		if resolveResponse.GetErrorMessage() != "" {
+			return nil, errors.New(resolveResponse.GetErrorMessage())
+		}
+		return stepArgFromParameter(resolveResponse.GetParameter())
+	}
+}
+
+func stepArgFromParameter(parameter *gauge_messages.Parameter) (*stepArg, error) {
+	if parameter == nil {
+		return nil, errors.New("Resolved parameter is empty")
+	}
+	if parameter.GetParameterType() == gauge_messages.Parameter_Special_Table {
+		return &stepArg{table: *(tableFrom(parameter.GetTable())), argType: specialTable}, nil
+	}
+	return &stepArg{value: parameter.GetValue(), argType: specialString}, nil
+}
+
+func initializePredefinedResolvers(sourceFile string) map[string]resolverFn {
 	return map[string]resolverFn{
 		"file": func(filePath string) (*stepArg, error) {
-			fileContent, err := common.ReadFileContents(filePath)
+			fileContent, source, err := readSpecialParamFile(sourceFile, filePath)
 			if err != nil {
 				return nil, err
 			}
-			return &stepArg{value: fileContent, argType: specialString}, nil
+			return &stepArg{value: fileContent, argType: specialString, source: source}, nil
 		},
 		"table": func(filePath string) (*stepArg, error) {
-			csv, err := common.ReadFileContents(filePath)
+			csv, source, err := readSpecialParamFile(sourceFile, filePath)
 			if err != nil {
 				return nil, err
 			}
@@ -130,9 +271,103 @@ func initializePredefinedResolvers() map[string]resolverFn {
 			if err != nil {
 				return nil, err
 			}
-			return &stepArg{table: *csvTable, argType: specialTable}, nil
+			return &stepArg{table: *csvTable, argType: specialTable, source: source}, nil
 		},
+		envParamPrefix: resolveEnvParam,
+		"json": func(filePath string) (*stepArg, error) {
+			jsonContents, source, err := readSpecialParamFile(sourceFile, filePath)
+			if err != nil {
+				return nil, err
+			}
+			jsonTable, err := convertJsonToTable(jsonContents)
+			if err != nil {
+				return nil, err
+			}
+			return &stepArg{table: *jsonTable, argType: specialTable, source: source}, nil
+		},
+		"yaml": func(filePath string) (*stepArg, error) {
+			yamlContents, source, err := readSpecialParamFile(sourceFile, filePath)
+			if err != nil {
+				return nil, err
+			}
+			yamlTable, err := convertYamlToTable(yamlContents)
+			if err != nil {
+				return nil, err
+			}
+			return &stepArg{table: *yamlTable, argType: specialTable, source: source}, nil
+		},
+	}
+}
+
+// Reads the file of a special param and returns its contents along with the path of the file that was loaded
+func readSpecialParamFile(sourceFile string, filePath string) (string, string, error) {
+	loadedFile, err := findSpecialParamFile(sourceFile, filePath)
+	if err != nil {
+		return "", "", err
+	}
+	contents, err := common.ReadFileContents(loadedFile)
+	if err != nil {
+		return "", "", err
+	}
+	return contents, specialParamSource(loadedFile), nil
+}
+
+// Relative paths are looked up against the directory of the spec or concept file first and then against the project root.
+// Files outside the project are rejected unless explicitly allowed.
+func findSpecialParamFile(sourceFile string, filePath string) (string, error) {
+	candidates := make([]string, 0)
+	if !filepath.IsAbs(filePath) && sourceFile != "" {
+		candidates = append(candidates, filepath.Join(filepath.Dir(sourceFile), filePath))
+	}
+	if !filepath.IsAbs(filePath) && config.ProjectRoot != "" {
+		candidates = append(candidates, filepath.Join(config.ProjectRoot, filePath))
+	}
+	candidates = append(candidates, filePath)
+	for _, candidate := range candidates {
+		if !common.FileExists(candidate) {
+			continue
+		}
+		if !config.AllowSpecialParamsOutsideProject() && !isInsideProject(candidate) {
+			return "", specialParamOutsideProjectError{message: fmt.Sprintf("File %s is outside the project. Set %s to true to allow it", filePath, "allow_special_params_outside_project")}
+		}
+		return candidate, nil
+	}
+	return "", errors.New(fmt.Sprintf("File %s not found", filePath))
+}
+
+func isInsideProject(filePath string) bool {
+	if config.ProjectRoot == "" {
+		return true
 	}
+	relativePath, err := filepath.Rel(evaluatedPath(config.ProjectRoot), evaluatedPath(filePath))
+	if err != nil {
+		return false
+	}
+	return relativePath != ".." && !strings.HasPrefix(relativePath, ".."+string(filepath.Separator))
+}
+
+func evaluatedPath(filePath string) string {
+	absolutePath, err := filepath.Abs(filePath)
+	if err != nil {
+		return filePath
+	}
+	evaluated, err := filepath.EvalSymlinks(absolutePath)
+	if err != nil {
+		return absolutePath
+	}
+	return evaluated
+}
+
+// Path of the loaded file as shown in the resolved step, relative to the project root when the file is inside the project
+func specialParamSource(filePath string) string {
+	if config.ProjectRoot == "" || !isInsideProject(filePath) {
+		return evaluatedPath(filePath)
+	}
+	relativePath, err := filepath.Rel(evaluatedPath(config.ProjectRoot), evaluatedPath(filePath))
+	if err != nil {
+		return evaluatedPath(filePath)
+	}
+	return filepath.ToSlash(relativePath)
 }
 
 func convertCsvToTable(csvContents string) (*table, error) {
@@ -152,6 +387,205 @@ func convertCsvToTable(csvContents string) (*table, error) {
 	return table, nil
 }
 
+// Converts a json list of objects to a table. Headers are the union of the keys of all objects in the order
+// they are first written, nested objects and lists are kept as json in the cell.
+func convertJsonToTable(jsonContents string) (*table, error) {
+	var objects []map[string]json.RawMessage
+	if err := json.Unmarshal([]byte(jsonContents), &objects); err != nil {
+		if _, ok := err.(*json.UnmarshalTypeError); ok {
+			return nil, errors.New("JSON should be a list of objects")
+		}
+		return nil, err
+	}
+	var rawObjects []json.RawMessage
+	if err := json.Unmarshal([]byte(jsonContents), &rawObjects); err != nil {
+		return nil, err
+	}
+	records := make([]*tableRecord, 0)
+	for i, object := range objects {
+		if object == nil {
+			return nil, errors.New("JSON should be a list of objects")
+		}
+		record := &tableRecord{values: make(map[string]string)}
+		for _, key := range jsonObjectKeys(rawObjects[i]) {
+			cellValue, err := jsonCellValue(object[key])
+			if err != nil {
+				return nil, err
+			}
+			record.add(key, cellValue)
+		}
+		records = append(records, record)
+	}
+	return convertRecordsToTable(records)
+}
+
+// Keys of a json object in the order they are written, as decoding the object to a map loses the order.
+// The object is expected to be valid json.
+func jsonObjectKeys(rawObject json.RawMessage) []string {
+	keys := make([]string, 0)
+	depth := 0
+	inString, escaped, expectKey := false, false, false
+	keyStart := 0
+	for i, b := range rawObject {
+		if inString {
+			if escaped {
+				escaped = false
+			} else if b == '\\' {
+				escaped = true
+			} else if b == '"' {
+				inString = false
+				if depth == 1 && expectKey {
+					var key string
+					if err := json.Unmarshal(rawObject[keyStart:i+1], &key); err == nil {
+						keys = append(keys, key)
+					}
+					expectKey = false
+				}
+			}
+			continue
+		}
+		switch b {
+		case '"':
+			inString = true
+			keyStart = i
+		case '{', '[':
+			depth++
+			expectKey = depth == 1 && b == '{'
+		case '}', ']':
+			depth--
+		case ',':
+			expectKey = depth == 1
+		}
+	}
+	return keys
+}
+
+func jsonCellValue(rawValue json.RawMessage) (string, error) {
+	var value interface{}
+	if err := json.Unmarshal(rawValue, &value); err != nil {
+		return "", err
+	}
+	switch value.(type) {
+	case nil:
+		return "", nil
+	case string:
+		return value.(string), nil
+	case map[string]interface{}, []interface{}:
+		var compacted bytes.Buffer
+		if err := json.Compact(&compacted, rawValue); err != nil {
+			return "", err
+		}
+		return compacted.String(), nil
+	}
+	return string(rawValue), nil
+}
+
+// Converts a yaml list of objects to a table. Headers are the union of the keys of all objects,
+// nested objects and lists are kept as json in the cell.
+func convertYamlToTable(yamlContents string) (*table, error) {
+	var objects []yaml.MapSlice
+	if err := yaml.Unmarshal([]byte(yamlContents), &objects); err != nil {
+		return nil, errors.New(fmt.Sprintf("YAML should be a list of objects. %s", err.Error()))
+	}
+	records := make([]*tableRecord, 0)
+	for _, object := range objects {
+		record := &tableRecord{values: make(map[string]string)}
+		for _, item := range object {
+			cellValue, err := yamlCellValue(item.Value)
+			if err != nil {
+				return nil, err
+			}
+			record.add(fmt.Sprint(item.Key), cellValue)
+		}
+		records = append(records, record)
+	}
+	return convertRecordsToTable(records)
+}
+
+func yamlCellValue(value interface{}) (string, error) {
+	switch value.(type) {
+	case nil:
+		return "", nil
+	case string:
+		return value.(string), nil
+	case yaml.MapSlice, []interface{}:
+		var buffer bytes.Buffer
+		err := writeYamlAsJson(&buffer, value)
+		return buffer.String(), err
+	}
+	return fmt.Sprint(value), nil
+}
+
+func writeYamlAsJson(buffer *bytes.Buffer, value interface{}) error {
+	switch value.(type) {
+	case yaml.MapSlice:
+		buffer.WriteString("{")
+		for i, item := range value.(yaml.MapSlice) {
+			if i > 0 {
+				buffer.WriteString(",")
+			}
+			key, _ := json.Marshal(fmt.Sprint(item.Key))
+			buffer.Write(key)
+			buffer.WriteString(":")
+			if err := writeYamlAsJson(buffer, item.Value); err != nil {
+				return err
+			}
+		}
+		buffer.WriteString("}")
+	case []interface{}:
+		buffer.WriteString("[")
+		for i, element := range value.([]interface{}) {
+			if i > 0 {
+				buffer.WriteString(",")
+			}
+			if err := writeYamlAsJson(buffer, element); err != nil {
+				return err
+			}
+		}
+		buffer.WriteString("]")
+	default:
+		jsonValue, err := json.Marshal(value)
+		if err != nil {
+			return err
+		}
+		buffer.Write(jsonValue)
+	}
+	return nil
+}
+
+func (record *tableRecord) add(key string, value string) {
+	if _, found := record.values[key]; !found {
+		record.keys = append(record.keys, key)
+	}
+	record.values[key] = value
+}
+
+func convertRecordsToTable(records []*tableRecord) (*table, error) {
+	headers := make([]string, 0)
+	isHeader := make(map[string]bool)
+	for _, record := range records {
+		for _, key := range record.keys {
+			if !isHeader[key] {
+				isHeader[key] = true
+				headers = append(headers, key)
+			}
+		}
+	}
+	if len(headers) == 0 {
+		return nil, errors.New("Table should have at least one column")
+	}
+	table := new(table)
+	table.addHeaders(headers)
+	for _, record := range records {
+		row := make([]string, 0)
+		for _, header := range headers {
+			row = append(row, record.values[header])
+		}
+		table.addRowValues(row)
+	}
+	return table, nil
+}
+
 func (resolver *specialTypeResolver) resolve(arg string) (*stepArg, error) {
 	//	fmt.Println(arg)
 	regEx := regexp.MustCompile("(.*):(.*)")
@@ -170,5 +604,9 @@ func (resolver *specialTypeResolver) getStepArg(specialType string, value string
 	if found {
 		return resolveFunc(value)
 	}
+	resolveFunc, found = resolver.registeredResolvers.get(specialType)
+	if found {
+		return resolveFunc(value)
+	}
 	return nil, invalidSpecialParamError{message: fmt.Sprintf("Resolver not found for special param <%s>", arg)}
 }
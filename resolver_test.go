@@ -17,7 +17,24 @@
 
 package main
 
-import . "gopkg.in/check.v1"
+import (
+	"bufio"
+	"encoding/binary"
+	"fmt"
+	"github.com/getgauge/gauge/config"
+	"github.com/getgauge/gauge/conn"
+	"github.com/getgauge/gauge/gauge_messages"
+	"github.com/golang/protobuf/proto"
+	. "gopkg.in/check.v1"
+	"io"
+	"io/ioutil"
+	"net"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+)
 
 func (s *MySuite) TestParsingFileSpecialType(c *C) {
 	resolver := newSpecialTypeResolver()
@@ -50,6 +67,69 @@ func (s *MySuite) TestConvertCsvToTable(c *C) {
 	c.Assert(nameColumn[1].value, Equals, "bar")
 }
 
+func (s *MySuite) TestConvertJsonToTable(c *C) {
+	table, err := convertJsonToTable(`[
+	{"id": 1, "name": "foo", "active": true},
+	{"id": 2, "address": {"city": "Pune", "zip": "411001"}, "roles": ["admin", "dev"], "name": null}
+]`)
+
+	c.Assert(err, IsNil)
+	c.Assert(table.headers, DeepEquals, []string{"id", "name", "active", "address", "roles"})
+	c.Assert(table.get("id")[1].value, Equals, "2")
+	c.Assert(table.get("name")[0].value, Equals, "foo")
+	c.Assert(table.get("name")[1].value, Equals, "")
+	c.Assert(table.get("active")[0].value, Equals, "true")
+	c.Assert(table.get("active")[1].value, Equals, "")
+	c.Assert(table.get("address")[0].value, Equals, "")
+	c.Assert(table.get("address")[1].value, Equals, `{"city":"Pune","zip":"411001"}`)
+	c.Assert(table.get("roles")[1].value, Equals, `["admin","dev"]`)
+}
+
+func (s *MySuite) TestConvertJsonToTableKeepsTheOrderOfKeys(c *C) {
+	table, err := convertJsonToTable(`[{"zone": "a,b", "id": {"x": 1, "y": "}"}, "na\"me": "foo"}]`)
+
+	c.Assert(err, IsNil)
+	c.Assert(table.headers, DeepEquals, []string{"zone", "id", "na\"me"})
+	c.Assert(table.get("id")[0].value, Equals, `{"x":1,"y":"}"}`)
+}
+
+func (s *MySuite) TestConvertJsonWhichIsNotAListOfObjectsToTable(c *C) {
+	_, err := convertJsonToTable(`{"id": 1}`)
+	c.Assert(err.Error(), Equals, "JSON should be a list of objects")
+
+	_, err = convertJsonToTable(`[1, 2]`)
+	c.Assert(err.Error(), Equals, "JSON should be a list of objects")
+
+	_, err = convertJsonToTable(`[]`)
+	c.Assert(err.Error(), Equals, "Table should have at least one column")
+}
+
+func (s *MySuite) TestConvertYamlToTable(c *C) {
+	table, err := convertYamlToTable(`
+- id: 1
+  name: foo
+- id: 2
+  address:
+    city: Pune
+    zip: "411001"
+  roles: [admin, dev]
+`)
+
+	c.Assert(err, IsNil)
+	c.Assert(table.headers, DeepEquals, []string{"id", "name", "address", "roles"})
+	c.Assert(table.get("id")[0].value, Equals, "1")
+	c.Assert(table.get("name")[1].value, Equals, "")
+	c.Assert(table.get("address")[1].value, Equals, `{"city":"Pune","zip":"411001"}`)
+	c.Assert(table.get("roles")[1].value, Equals, `["admin","dev"]`)
+}
+
+func (s *MySuite) TestConvertYamlWhichIsNotAListOfObjectsToTable(c *C) {
+	_, err := convertYamlToTable("id: 1")
+
+	c.Assert(err, NotNil)
+	c.Assert(strings.HasPrefix(err.Error(), "YAML should be a list of objects."), Equals, true)
+}
+
 func (s *MySuite) TestParsingUnknownSpecialType(c *C) {
 	resolver := newSpecialTypeResolver()
 
@@ -57,6 +137,263 @@ func (s *MySuite) TestParsingUnknownSpecialType(c *C) {
 	c.Assert(err.Error(), Equals, "Resolver not found for special param <unknown:foo>")
 }
 
+func (s *MySuite) TestResolvingRegisteredSpecialType(c *C) {
+	registerSpecialParamResolver("fixture", func(value string) (*stepArg, error) {
+		return &stepArg{value: "fixture " + value, argType: specialString}, nil
+	})
+	defer registeredResolvers.remove("fixture")
+
+	stepArg, err := newSpecialTypeResolver().resolve("fixture:users")
+	c.Assert(err, IsNil)
+	c.Assert(stepArg.value, Equals, "fixture users")
+	c.Assert(stepArg.argType, Equals, specialString)
+	c.Assert(stepArg.name, Equals, "fixture:users")
+}
+
+func (s *MySuite) TestPredefinedSpecialTypeCannotBeRegistered(c *C) {
+	err := registerSpecialParamResolver("file", func(value string) (*stepArg, error) {
+		return nil, nil
+	})
+
+	c.Assert(err.Error(), Equals, "Special param prefix 'file' is predefined and cannot be registered")
+	_, found := registeredResolvers.get("file")
+	c.Assert(found, Equals, false)
+}
+
+func (s *MySuite) TestParsingStepWithRegisteredSpecialParam(c *C) {
+	registerSpecialParamResolver("fixture", func(value string) (*stepArg, error) {
+		return &stepArg{value: "fixture " + value, argType: specialString}, nil
+	})
+	defer registeredResolvers.remove("fixture")
+	specText := SpecBuilder().specHeading("Spec Heading").scenarioHeading("Scenario Heading").step("create <fixture:users>").String()
+
+	spec, result := new(specParser).parse(specText, new(conceptDictionary))
+
+	c.Assert(result.ok, Equals, true)
+	c.Assert(len(result.warnings), Equals, 0)
+	step := spec.scenarios[0].steps[0]
+	c.Assert(step.args[0].argType, Equals, specialString)
+	c.Assert(step.args[0].value, Equals, "fixture users")
+}
+
+func (s *MySuite) TestRegisteringSpecialParamResolversOfConnection(c *C) {
+	gaugeEnd, providerEnd := net.Pipe()
+	defer gaugeEnd.Close()
+	table := &gauge_messages.ProtoTable{Headers: &gauge_messages.ProtoTableRow{Cells: []string{"id", "name"}},
+		Rows: []*gauge_messages.ProtoTableRow{&gauge_messages.ProtoTableRow{Cells: []string{"1", "foo"}}}}
+	go respondToSpecialParamRequests(providerEnd, []string{"fixture"}, &gauge_messages.Parameter{ParameterType: gauge_messages.Parameter_Special_Table.Enum(), Table: table})
+	defer registeredResolvers.remove("fixture")
+
+	prefixes, err := registerSpecialParamResolvers(newResolverConnection(gaugeEnd))
+	c.Assert(err, IsNil)
+	c.Assert(prefixes, DeepEquals, []string{"fixture"})
+
+	stepArg, err := newSpecialTypeResolver().resolve("fixture:users")
+	c.Assert(err, IsNil)
+	c.Assert(stepArg.argType, Equals, specialTable)
+	c.Assert(stepArg.table.headers, DeepEquals, []string{"id", "name"})
+	c.Assert(stepArg.table.get("name")[0].value, Equals, "foo")
+}
+
+func (s *MySuite) TestResolvingSpecialParamWithErrorFromConnection(c *C) {
+	gaugeEnd, providerEnd := net.Pipe()
+	defer gaugeEnd.Close()
+	go respondToSpecialParamRequests(providerEnd, []string{"fixture"}, nil)
+	defer registeredResolvers.remove("fixture")
+
+	registerSpecialParamResolvers(newResolverConnection(gaugeEnd))
+	_, err := newSpecialTypeResolver().resolve("fixture:users")
+
+	c.Assert(err.Error(), Equals, "Fixture users not found")
+}
+
+func (s *MySuite) TestResolvingSpecialParamsOfAConnectionConcurrently(c *C) {
+	gaugeEnd, providerEnd := tcpConnectionPair(c)
+	defer gaugeEnd.Close()
+	gaugeEnd.SetDeadline(time.Now().Add(10 * time.Second))
+	go respondToSpecialParamRequests(providerEnd, []string{"fixture"}, nil)
+	defer registeredResolvers.remove("fixture")
+	registerSpecialParamResolvers(newResolverConnection(gaugeEnd))
+
+	errs := make([]error, 20)
+	var wg sync.WaitGroup
+	for i := range errs {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			_, errs[i] = newSpecialTypeResolverFor(fmt.Sprintf("spec%d.spec", i)).resolve(fmt.Sprintf("fixture:users%d", i))
+		}(i)
+	}
+	wg.Wait()
+
+	for i, err := range errs {
+		c.Assert(err.Error(), Equals, fmt.Sprintf("Fixture users%d not found", i))
+	}
+}
+
+func (s *MySuite) TestOnlyRunnersDeclaringSpecialParamResolverAreAskedForPrefixes(c *C) {
+	gaugeEnd, providerEnd := net.Pipe()
+	defer gaugeEnd.Close()
+	go respondToSpecialParamRequests(providerEnd, []string{"fixture"}, nil)
+	defer registeredResolvers.remove("fixture")
+
+	registerRunnerSpecialParamResolvers(&testRunner{connection: gaugeEnd})
+	_, found := registeredResolvers.get("fixture")
+	c.Assert(found, Equals, false)
+
+	registerRunnerSpecialParamResolvers(&testRunner{connection: gaugeEnd, specialParamResolver: true})
+	_, found = registeredResolvers.get("fixture")
+	c.Assert(found, Equals, true)
+}
+
+func (s *MySuite) TestSpecialParamsOfARestartedRunnerAreResolvedOverItsNewConnection(c *C) {
+	oldGaugeEnd, oldProviderEnd := net.Pipe()
+	go respondToSpecialParamRequests(oldProviderEnd, []string{"fixture"}, nil)
+	defer registeredResolvers.remove("fixture")
+	runner := &testRunner{connection: oldGaugeEnd, specialParamResolver: true}
+	registerRunnerSpecialParamResolvers(runner)
+
+	newGaugeEnd, newProviderEnd := net.Pipe()
+	defer newGaugeEnd.Close()
+	go respondToSpecialParamRequests(newProviderEnd, []string{"fixture"}, &gauge_messages.Parameter{ParameterType: gauge_messages.Parameter_Special_String.Enum(), Value: proto.String("restarted")})
+	oldGaugeEnd.Close()
+	*runner = testRunner{connection: newGaugeEnd, specialParamResolver: true}
+
+	stepArg, err := newSpecialTypeResolver().resolve("fixture:users")
+	c.Assert(err, IsNil)
+	c.Assert(stepArg.value, Equals, "restarted")
+}
+
+func respondToSpecialParamRequests(connection net.Conn, prefixes []string, parameter *gauge_messages.Parameter) {
+	defer connection.Close()
+	reader := bufio.NewReader(connection)
+	for {
+		messageLength, err := binary.ReadUvarint(reader)
+		if err != nil {
+			return
+		}
+		data := make([]byte, messageLength)
+		if _, err := io.ReadFull(reader, data); err != nil {
+			return
+		}
+		request := &gauge_messages.Message{}
+		proto.Unmarshal(data, request)
+		response := &gauge_messages.Message{MessageId: request.MessageId}
+		if request.GetMessageType() == gauge_messages.Message_SpecialParamPrefixesRequest {
+			response.MessageType = gauge_messages.Message_SpecialParamPrefixesResponse.Enum()
+			response.SpecialParamPrefixesResponse = &gauge_messages.SpecialParamPrefixesResponse{Prefixes: prefixes}
+		} else {
+			response.MessageType = gauge_messages.Message_ResolveSpecialParamResponse.Enum()
+			response.ResolveSpecialParamResponse = &gauge_messages.ResolveSpecialParamResponse{Parameter: parameter}
+			if parameter == nil {
+				response.ResolveSpecialParamResponse.ErrorMessage = proto.String("Fixture " + request.GetResolveSpecialParamRequest().GetValue() + " not found")
+			}
+		}
+		responseBytes, _ := proto.Marshal(response)
+		conn.Write(connection, responseBytes)
+	}
+}
+
+// Unlike net.Pipe, writes to a tcp connection do not wait for the other end to read them
+func tcpConnectionPair(c *C) (net.Conn, net.Conn) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	c.Assert(err, IsNil)
+	defer listener.Close()
+	gaugeEnd, err := net.Dial("tcp", listener.Addr().String())
+	c.Assert(err, IsNil)
+	providerEnd, err := listener.Accept()
+	c.Assert(err, IsNil)
+	return gaugeEnd, providerEnd
+}
+
+func createSpecialParamProject(c *C, files map[string]string) string {
+	dir, err := ioutil.TempDir("", "gauge_special_params")
+	c.Assert(err, IsNil)
+	projectRoot := filepath.Join(dir, "project")
+	for name, contents := range files {
+		filePath := filepath.Join(dir, filepath.FromSlash(name))
+		c.Assert(os.MkdirAll(filepath.Dir(filePath), 0755), IsNil)
+		c.Assert(ioutil.WriteFile(filePath, []byte(contents), 0644), IsNil)
+	}
+	c.Assert(os.MkdirAll(projectRoot, 0755), IsNil)
+	return projectRoot
+}
+
+func (s *MySuite) TestSpecialParamFileIsResolvedRelativeToSpecFile(c *C) {
+	projectRoot := createSpecialParamProject(c, map[string]string{"project/specs/data.csv": "id\n1", "project/data.csv": "id\n2"})
+	defer os.RemoveAll(filepath.Dir(projectRoot))
+	oldProjectRoot := config.ProjectRoot
+	config.ProjectRoot = projectRoot
+	defer func() { config.ProjectRoot = oldProjectRoot }()
+
+	stepArg, err := newSpecialTypeResolverFor(filepath.Join(projectRoot, "specs", "first.spec")).resolve("table:data.csv")
+
+	c.Assert(err, IsNil)
+	c.Assert(stepArg.table.get("id")[0].value, Equals, "1")
+	c.Assert(stepArg.source, Equals, "specs/data.csv")
+}
+
+func (s *MySuite) TestSpecialParamFileIsResolvedRelativeToProjectRoot(c *C) {
+	projectRoot := createSpecialParamProject(c, map[string]string{"project/data.txt": "contents"})
+	defer os.RemoveAll(filepath.Dir(projectRoot))
+	oldProjectRoot := config.ProjectRoot
+	config.ProjectRoot = projectRoot
+	defer func() { config.ProjectRoot = oldProjectRoot }()
+
+	stepArg, err := newSpecialTypeResolverFor(filepath.Join(projectRoot, "specs", "first.spec")).resolve("file:data.txt")
+
+	c.Assert(err, IsNil)
+	c.Assert(stepArg.value, Equals, "contents")
+	c.Assert(stepArg.source, Equals, "data.txt")
+}
+
+func (s *MySuite) TestSpecialParamFileOutsideProjectIsRejected(c *C) {
+	projectRoot := createSpecialParamProject(c, map[string]string{"outside.txt": "secret"})
+	defer os.RemoveAll(filepath.Dir(projectRoot))
+	oldProjectRoot := config.ProjectRoot
+	config.ProjectRoot = projectRoot
+	defer func() { config.ProjectRoot = oldProjectRoot }()
+
+	_, err := newSpecialTypeResolverFor(filepath.Join(projectRoot, "first.spec")).resolve("file:../outside.txt")
+
+	c.Assert(err, FitsTypeOf, specialParamOutsideProjectError{})
+}
+
+func (s *MySuite) TestSpecialParamFileNotFound(c *C) {
+	projectRoot := createSpecialParamProject(c, map[string]string{})
+	defer os.RemoveAll(filepath.Dir(projectRoot))
+	oldProjectRoot := config.ProjectRoot
+	config.ProjectRoot = projectRoot
+	defer func() { config.ProjectRoot = oldProjectRoot }()
+
+	_, err := newSpecialTypeResolverFor(filepath.Join(projectRoot, "first.spec")).resolve("file:missing.txt")
+
+	c.Assert(err.Error(), Equals, "File missing.txt not found")
+}
+
+func (s *MySuite) TestResolvedParamNameHasTheLoadedFile(c *C) {
+	projectRoot := createSpecialParamProject(c, map[string]string{"project/specs/data.txt": "contents"})
+	defer os.RemoveAll(filepath.Dir(projectRoot))
+	oldProjectRoot := config.ProjectRoot
+	config.ProjectRoot = projectRoot
+	defer func() { config.ProjectRoot = oldProjectRoot }()
+	tokens := []*token{
+		&token{kind: specKind, value: "Spec Heading", lineNo: 1},
+		&token{kind: scenarioKind, value: "Scenario Heading", lineNo: 2},
+		&token{kind: stepKind, value: "print {special}", args: []string{"file:data.txt"}, lineNo: 3},
+	}
+
+	parser := &specParser{fileName: filepath.Join(projectRoot, "specs", "first.spec")}
+	spec, result := parser.createSpecification(tokens, new(conceptDictionary))
+	c.Assert(result.ok, Equals, true)
+	step := spec.scenarios[0].steps[0]
+	parameters := new(paramResolver).getResolvedParams(step, nil, nil)
+
+	c.Assert(step.args[0].name, Equals, "file:data.txt")
+	c.Assert(parameters[0].GetName(), Equals, "file:specs/data.txt")
+	c.Assert(parameters[0].GetValue(), Equals, "contents")
+}
+
 func (s *MySuite) TestPopulatingConceptLookup(c *C) {
 	parser := new(specParser)
 	conceptDictionary := new(conceptDictionary)
@@ -0,0 +1,89 @@
+// Copyright 2015 ThoughtWorks, Inc.
+
+// This file is part of Gauge.
+
+// Gauge is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+
+// Gauge is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+
+// You should have received a copy of the GNU General Public License
+// along with Gauge.  If not, see <http://www.gnu.org/licenses/>.
+
+package main
+
+import (
+	"encoding/json"
+	"github.com/getgauge/common"
+	"github.com/getgauge/gauge/gauge_messages"
+	"github.com/getgauge/gauge/logger"
+	"io/ioutil"
+)
+
+// jsonResult is the document written by --result-json.
+//
+// suiteResult is the ProtoSuiteResult of the run, serialized with the field names
+// of the gauge_messages definitions (specResults, protoSpec, items, scenarioHeading, ...),
+// so consumers can rely on the same schema as the plugins do. Enum fields are written as
+// numbers: itemType is 1=Step, 2=Comment, 3=Concept, 4=Scenario, 5=TableDrivenScenario,
+// 6=Table, 7=Tags. Table-driven scenarios hold one scenario per executed data table row.
+// Screenshots are base64 encoded. Execution times are in milliseconds.
+//
+// specsSkippedCount counts the specs that were not executed, either because they were
+// filtered out, marked as skipped or because their execution stream could not be started.
+// The latter two are listed in skippedSpecs along with the reason.
+type jsonResult struct {
+	SuiteResult       *gauge_messages.ProtoSuiteResult `json:"suiteResult"`
+	SpecsSkippedCount int                              `json:"specsSkippedCount"`
+	SkippedSpecs      []*skippedSpec                   `json:"skippedSpecs"`
+}
+
+type skippedSpec struct {
+	FileName string `json:"fileName"`
+	Reason   string `json:"reason"`
+}
+
+func writeJSONResult(fileName string, result *suiteResult, specsSkipped int) {
+	contents, err := createJSONResult(result, specsSkipped)
+	if err == nil {
+		err = ioutil.WriteFile(fileName, contents, common.NewFilePermissions)
+	}
+	if err != nil {
+		logger.Log.Error("Failed to write JSON result to %s. %s\n", fileName, err.Error())
+		return
+	}
+	logger.Log.Info("JSON result written to %s\n", fileName)
+}
+
+func createJSONResult(result *suiteResult, specsSkipped int) ([]byte, error) {
+	skippedSpecs := getSkippedSpecs(result)
+	return json.MarshalIndent(&jsonResult{
+		SuiteResult:       convertToProtoSuiteResult(result),
+		SpecsSkippedCount: specsSkipped + len(skippedSpecs),
+		SkippedSpecs:      skippedSpecs,
+	}, "", "  ")
+}
+
+func getSkippedSpecs(result *suiteResult) []*skippedSpec {
+	skippedSpecs := make([]*skippedSpec, 0)
+	for _, specResult := range result.specResults {
+		if specResult.isSkipped {
+			skippedSpecs = append(skippedSpecs, &skippedSpec{FileName: specResult.protoSpec.GetFileName(), Reason: specResult.skipReason})
+		}
+	}
+	for _, unhandledErr := range result.unhandledErrors {
+		streamErr, ok := unhandledErr.(streamExecError)
+		if !ok {
+			continue
+		}
+		for _, specName := range streamErr.specsSkipped {
+			skippedSpecs = append(skippedSpecs, &skippedSpec{FileName: specName, Reason: streamErr.message})
+		}
+	}
+	return skippedSpecs
+}
@@ -0,0 +1,61 @@
+// Copyright 2015 ThoughtWorks, Inc.
+
+// This file is part of Gauge.
+
+// Gauge is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+
+// Gauge is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+
+// You should have received a copy of the GNU General Public License
+// along with Gauge.  If not, see <http://www.gnu.org/licenses/>.
+
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"github.com/getgauge/gauge/gauge_messages"
+	"github.com/golang/protobuf/proto"
+	. "gopkg.in/check.v1"
+)
+
+func (s *MySuite) TestJSONResultContainsSuiteResultAndSkippedSpecs(c *C) {
+	row := &gauge_messages.ProtoScenario{ScenarioHeading: proto.String("scenario"), Failed: proto.Bool(true)}
+	tableDrivenItem := &gauge_messages.ProtoItem{ItemType: gauge_messages.ProtoItem_TableDrivenScenario.Enum(), TableDrivenScenario: &gauge_messages.ProtoTableDrivenScenario{Scenarios: []*gauge_messages.ProtoScenario{row}}}
+	protoSpec := &gauge_messages.ProtoSpec{SpecHeading: proto.String("Spec heading"), FileName: proto.String("foo.spec"), Items: []*gauge_messages.ProtoItem{tableDrivenItem}}
+	result := newSuiteResult()
+	result.addSpecResult(&specResult{protoSpec: protoSpec, isFailed: true, scenarioCount: 1, scenarioFailedCount: 1})
+	result.unhandledErrors = []error{streamExecError{specsSkipped: []string{"bar.spec", "baz.spec"}, message: "Failed to start runner"}}
+
+	contents, err := createJSONResult(result, 1)
+	c.Assert(err, IsNil)
+	jsonResult := new(jsonResult)
+	c.Assert(json.Unmarshal(contents, jsonResult), IsNil)
+
+	c.Assert(jsonResult.SpecsSkippedCount, Equals, 3)
+	c.Assert(len(jsonResult.SkippedSpecs), Equals, 2)
+	c.Assert(jsonResult.SkippedSpecs[0].FileName, Equals, "bar.spec")
+	c.Assert(jsonResult.SkippedSpecs[0].Reason, Equals, "Failed to start runner")
+	c.Assert(jsonResult.SuiteResult.GetFailed(), Equals, true)
+	c.Assert(jsonResult.SuiteResult.GetSpecsFailedCount(), Equals, int32(1))
+	specResults := jsonResult.SuiteResult.GetSpecResults()
+	c.Assert(len(specResults), Equals, 1)
+	c.Assert(specResults[0].GetScenarioFailedCount(), Equals, int32(1))
+	c.Assert(specResults[0].GetProtoSpec().GetFileName(), Equals, "foo.spec")
+	items := specResults[0].GetProtoSpec().GetItems()
+	c.Assert(items[0].GetItemType(), Equals, gauge_messages.ProtoItem_TableDrivenScenario)
+	c.Assert(items[0].GetTableDrivenScenario().GetScenarios()[0].GetScenarioHeading(), Equals, "scenario")
+}
+
+func (s *MySuite) TestSkippedSpecsIgnoresOtherErrors(c *C) {
+	result := newSuiteResult()
+	result.unhandledErrors = []error{errors.New("some error")}
+
+	c.Assert(len(getSkippedSpecs(result)), Equals, 0)
+}
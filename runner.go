@@ -39,6 +39,11 @@ type testRunner struct {
 	cmd          *exec.Cmd
 	connection   net.Conn
 	errorChannel chan error
+	manifest     *manifest
+	// Runners which resolve special params declare it in their json, only they are asked for the prefixes they resolve
+	specialParamResolver bool
+	// Set when a runner which stopped responding could not be restarted. No more messages are sent to it after that.
+	restartError error
 }
 
 type runner struct {
@@ -56,8 +61,9 @@ type runner struct {
 		Linux   []string
 		Darwin  []string
 	}
-	Lib                 string
-	GaugeVersionSupport versionSupport
+	Lib                  string
+	GaugeVersionSupport  versionSupport
+	SpecialParamResolver bool
 }
 
 func executeInitHookForRunner(language string) error {
@@ -141,6 +147,32 @@ func (testRunner *testRunner) kill(writer executionLogger) error {
 	return nil
 }
 
+// Forcefully kills a runner which is not responding and starts a new one in its place.
+// The runner is replaced in place so that everything holding on to it talks to the new process. The init messages
+// are sent to the new runner to bring it to the state of the execution it is taking over.
+func (testRunner *testRunner) restart(writer executionLogger, initMessages ...*gauge_messages.Message) error {
+	if testRunner.connection != nil {
+		testRunner.connection.Close()
+	}
+	if testRunner.isStillRunning() {
+		if err := testRunner.killRunner(); err != nil {
+			writer.Debug("Error while killing runner: %s", err)
+		}
+	}
+	newRunner, err := startRunnerAndMakeConnection(testRunner.manifest, writer)
+	if err != nil {
+		testRunner.restartError = err
+		return err
+	}
+	*testRunner = *newRunner
+	for _, message := range initMessages {
+		if result := executeAndGetStatus(testRunner, message, writer); result.GetFailed() {
+			writer.Warning("%s failed on the restarted runner. %s", message.GetMessageType(), result.GetErrorMessage())
+		}
+	}
+	return nil
+}
+
 func (testRunner *testRunner) killRunner() error {
 	return testRunner.cmd.Process.Kill()
 }
@@ -176,9 +208,9 @@ func startRunner(manifest *manifest, port string, writer executionLogger) (*test
 		return nil, err
 	}
 	// Wait for the process to exit so we will get a detailed error message
-	errChannel := make(chan error)
+	errChannel := make(chan error, 1)
 	waitAndGetErrorMessage(errChannel, cmd, writer)
-	return &testRunner{cmd: cmd, errorChannel: errChannel}, nil
+	return &testRunner{cmd: cmd, errorChannel: errChannel, manifest: manifest, specialParamResolver: r.SpecialParamResolver}, nil
 }
 
 func getLanguageJSONFilePath(manifest *manifest, r *runner) (string, error) {
@@ -202,7 +234,11 @@ func waitAndGetErrorMessage(errChannel chan error, cmd *exec.Cmd, writer executi
 		err := cmd.Wait()
 		if err != nil {
 			writer.Debug("Runner exited with error: %s", err)
-			errChannel <- errors.New(fmt.Sprintf("Runner exited with error: %s\n", err.Error()))
+			// Nobody listens once the runner is connected, so the error must not block the exit of this goroutine
+			select {
+			case errChannel <- errors.New(fmt.Sprintf("Runner exited with error: %s\n", err.Error())):
+			default:
+			}
 		}
 	}()
 }
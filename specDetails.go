@@ -42,6 +42,7 @@ func (specInfoGatherer *specInfoGatherer) makeListOfAvailableSteps(runner *testR
 	specInfoGatherer.availableStepsMap = make(map[string]*stepValue)
 	specInfoGatherer.fileToStepsMap = make(map[string][]*step)
 	runner = specInfoGatherer.getStepsFromRunner(runner)
+	registerRunnerSpecialParamResolvers(runner)
 
 	// Concepts parsed first because we need to create a concept dictionary that spec parsing can use
 	specInfoGatherer.findAllStepsFromConcepts()
@@ -63,9 +64,9 @@ func (specInfoGatherer *specInfoGatherer) findAllStepsFromSpecs() {
 }
 
 func (specInfoGatherer *specInfoGatherer) createConceptsDictionary() {
-	var result *parseResult
-	specInfoGatherer.conceptDictionary, result = createConceptsDictionary(true)
-	specInfoGatherer.handleParseFailures([]*parseResult{result})
+	var results []*parseResult
+	specInfoGatherer.conceptDictionary, results = createConceptsDictionary(true)
+	specInfoGatherer.handleParseFailures(results)
 }
 
 func (specInfoGatherer *specInfoGatherer) handleParseFailures(parseResults []*parseResult) {
@@ -197,8 +198,8 @@ func (specInfoGatherer *specInfoGatherer) addToAvailableSpecs(specs []*specifica
 
 func (specInfoGatherer *specInfoGatherer) addConcept(fileName string) {
 	logger.ApiLog.Info("Concept added/modified: %s", fileName)
-	if err := addConcepts(fileName, specInfoGatherer.getDictionary()); err != nil {
-		logger.ApiLog.Error("Concept parse failure: %s %s", fileName, err)
+	if result := addConcepts(fileName, specInfoGatherer.getDictionary()); !result.ok {
+		logger.ApiLog.Error("Concept parse failure: %s", result.Error())
 		return
 	}
 	specInfoGatherer.findAllStepsFromConcepts()
@@ -246,7 +247,7 @@ func (specInfoGatherer *specInfoGatherer) createConceptInfos() []*gauge_messages
 	conceptInfos := make([]*gauge_messages.ConceptInfo, 0)
 	for _, concept := range specInfoGatherer.getDictionary().conceptsMap {
 		stepValue := createStepValue(concept.conceptStep)
-		conceptInfos = append(conceptInfos, &gauge_messages.ConceptInfo{StepValue: convertToProtoStepValue(&stepValue), Filepath: proto.String(concept.fileName), LineNumber: proto.Int(concept.conceptStep.lineNo)})
+		conceptInfos = append(conceptInfos, &gauge_messages.ConceptInfo{StepValue: convertToProtoStepValue(&stepValue), Filepath: proto.String(concept.fileName), LineNumber: proto.Int(concept.conceptStep.lineNo), Span: convertToProtoSpan(concept.conceptStep.span)})
 	}
 	return conceptInfos
 }
@@ -293,6 +294,7 @@ func (specInfoGatherer *specInfoGatherer) findAvailableStepsInSpecs(specs []*spe
 	for _, spec := range specs {
 		stepsInSpec := make([]*step, 0)
 		stepsInSpec = append(stepsInSpec, spec.contexts...)
+		stepsInSpec = append(stepsInSpec, spec.tearDownSteps...)
 		for _, scenario := range spec.scenarios {
 			stepsInSpec = append(stepsInSpec, scenario.steps...)
 		}
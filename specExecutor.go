@@ -25,11 +25,12 @@ import (
 	"github.com/golang/protobuf/proto"
 	"strconv"
 	"strings"
+	"time"
 )
 
 type specExecutor struct {
 	specification        *specification
-	dataTableIndex       indexRange
+	dataTableRows        []int
 	runner               *testRunner
 	conceptDictionary    *conceptDictionary
 	pluginHandler        *pluginHandler
@@ -37,6 +38,11 @@ type specExecutor struct {
 	specResult           *specResult
 	writer               executionLogger
 	currentTableRow      int
+	currentScenario      *scenario
+	scenarioTableRow     int
+	stepTimeout          time.Duration
+	scenarioTimeout      time.Duration
+	scenarioDeadline     time.Time
 }
 
 type indexRange struct {
@@ -44,28 +50,35 @@ type indexRange struct {
 	end   int
 }
 
-func (specExecutor *specExecutor) initialize(specificationToExecute *specification, runner *testRunner, pluginHandler *pluginHandler, writer executionLogger, tableRows indexRange) {
+func (specExecutor *specExecutor) initialize(specificationToExecute *specification, runner *testRunner, pluginHandler *pluginHandler, writer executionLogger, tableRows []int) {
 	specExecutor.specification = specificationToExecute
 	specExecutor.runner = runner
 	specExecutor.pluginHandler = pluginHandler
 	specExecutor.writer = writer
-	specExecutor.dataTableIndex = tableRows
+	specExecutor.dataTableRows = tableRows
 }
 
-func (e *specExecutor) executeBeforeSpecHook() *gauge_messages.ProtoExecutionResult {
-	initSpecDataStoreMessage := &gauge_messages.Message{MessageType: gauge_messages.Message_SpecDataStoreInit.Enum(),
+func newSpecDataStoreInitMessage() *gauge_messages.Message {
+	return &gauge_messages.Message{MessageType: gauge_messages.Message_SpecDataStoreInit.Enum(),
 		SpecDataStoreInitRequest: &gauge_messages.SpecDataStoreInitRequest{}}
-	initResult := executeAndGetStatus(e.runner, initSpecDataStoreMessage, e.writer)
+}
+
+func newSpecExecutionStartingMessage(executionInfo *gauge_messages.ExecutionInfo) *gauge_messages.Message {
+	return &gauge_messages.Message{MessageType: gauge_messages.Message_SpecExecutionStarting.Enum(),
+		SpecExecutionStartingRequest: &gauge_messages.SpecExecutionStartingRequest{CurrentExecutionInfo: executionInfo}}
+}
+
+func (e *specExecutor) executeBeforeSpecHook() *gauge_messages.ProtoExecutionResult {
+	e.setSpecTimeouts()
+	initResult := e.executeHookMessage(newSpecDataStoreInitMessage())
 	if initResult.GetFailed() {
 		e.writer.Warning("Spec data store didn't get initialized")
 	}
-
-	message := &gauge_messages.Message{MessageType: gauge_messages.Message_SpecExecutionStarting.Enum(),
-		SpecExecutionStartingRequest: &gauge_messages.SpecExecutionStartingRequest{CurrentExecutionInfo: e.currentExecutionInfo}}
-	return e.executeHook(message, e.specResult)
+	return e.executeHook(newSpecExecutionStartingMessage(e.currentExecutionInfo), e.specResult)
 }
 
 func (e *specExecutor) executeAfterSpecHook() *gauge_messages.ProtoExecutionResult {
+	e.setSpecTimeouts()
 	message := &gauge_messages.Message{MessageType: gauge_messages.Message_SpecExecutionEnding.Enum(),
 		SpecExecutionEndingRequest: &gauge_messages.SpecExecutionEndingRequest{CurrentExecutionInfo: e.currentExecutionInfo}}
 	return e.executeHook(message, e.specResult)
@@ -73,7 +86,7 @@ func (e *specExecutor) executeAfterSpecHook() *gauge_messages.ProtoExecutionResu
 
 func (e *specExecutor) executeHook(message *gauge_messages.Message, execTimeTracker execTimeTracker) *gauge_messages.ProtoExecutionResult {
 	e.pluginHandler.notifyPlugins(message)
-	executionResult := executeAndGetStatus(e.runner, message, e.writer)
+	executionResult := e.executeHookMessage(message)
 	execTimeTracker.addExecTime(executionResult.GetExecutionTime())
 	return executionResult
 }
@@ -81,7 +94,8 @@ func (e *specExecutor) executeHook(message *gauge_messages.Message, execTimeTrac
 func (specExecutor *specExecutor) execute() *specResult {
 	specInfo := &gauge_messages.SpecInfo{Name: proto.String(specExecutor.specification.heading.value),
 		FileName: proto.String(specExecutor.specification.fileName),
-		IsFailed: proto.Bool(false), Tags: getTagValue(specExecutor.specification.tags)}
+		IsFailed: proto.Bool(false), Tags: getTagValue(specExecutor.specification.tags),
+		Metadata: convertToProtoMetadata(specExecutor.specification.metadata)}
 	specExecutor.currentExecutionInfo = &gauge_messages.ExecutionInfo{CurrentSpec: specInfo}
 
 	specExecutor.writer.SpecHeading(specInfo.GetName())
@@ -90,6 +104,11 @@ func (specExecutor *specExecutor) execute() *specResult {
 	resolvedSpecItems := specExecutor.resolveItems(specExecutor.specification.getSpecItems())
 	specExecutor.specResult.addSpecItems(resolvedSpecItems)
 
+	if specExecutor.specification.skip != nil {
+		specExecutor.skipSpec(specExecutor.specification.skip.reason)
+		return specExecutor.specResult
+	}
+
 	beforeSpecHookStatus := specExecutor.executeBeforeSpecHook()
 	if beforeSpecHookStatus.GetFailed() {
 		addPreHook(specExecutor.specResult, beforeSpecHookStatus)
@@ -100,8 +119,7 @@ func (specExecutor *specExecutor) execute() *specResult {
 		}
 		dataTableRowCount := specExecutor.specification.dataTable.table.getRowCount()
 		if dataTableRowCount == 0 {
-			scenarioResult := specExecutor.executeScenarios()
-			specExecutor.specResult.addScenarioResults(scenarioResult)
+			specExecutor.executeScenariosWithDataTables()
 		} else {
 			specExecutor.executeTableDrivenScenarios()
 		}
@@ -115,13 +133,30 @@ func (specExecutor *specExecutor) execute() *specResult {
 	return specExecutor.specResult
 }
 
+// A skipped spec is reported along with all its scenarios, without running any of the hooks
+func (specExecutor *specExecutor) skipSpec(reason string) {
+	specExecutor.writer.Warning("Skipping specification: %s", reason)
+	specExecutor.specResult.skip(reason)
+	scenarioResults := make([]*scenarioResult, 0)
+	for _, scenario := range specExecutor.specification.scenarios {
+		scenarioResults = append(scenarioResults, specExecutor.skippedScenarioResult(scenario, scenario.heading.value, reason))
+	}
+	specExecutor.specResult.addScenarioResults(scenarioResults)
+}
+
 func (specExecutor *specExecutor) executeTableDrivenScenarios() {
+	if len(specExecutor.dataTableRows) == 0 {
+		return
+	}
 	var dataTableScenarioExecutionResult [][]*scenarioResult
-	//	dataTableRowCount := specExecutor.specification.dataTable.table.getRowCount()
-	for specExecutor.currentTableRow = specExecutor.dataTableIndex.start; specExecutor.currentTableRow <= specExecutor.dataTableIndex.end; specExecutor.currentTableRow++ {
+	for _, specExecutor.currentTableRow = range specExecutor.dataTableRows {
 		dataTableScenarioExecutionResult = append(dataTableScenarioExecutionResult, specExecutor.executeScenarios())
 	}
 	specExecutor.specResult.addTableDrivenScenarioResult(dataTableScenarioExecutionResult)
+	// Failed rows are reported as indexes of the data table, not of the executed rows
+	for i, executedRow := range specExecutor.specResult.failedDataTableRows {
+		specExecutor.specResult.failedDataTableRows[i] = int32(specExecutor.dataTableRows[executedRow])
+	}
 }
 
 func getTagValue(tags *tags) []string {
@@ -135,7 +170,7 @@ func getTagValue(tags *tags) []string {
 func (executor *specExecutor) executeBeforeScenarioHook(scenarioResult *scenarioResult) *gauge_messages.ProtoExecutionResult {
 	initScenarioDataStoreMessage := &gauge_messages.Message{MessageType: gauge_messages.Message_ScenarioDataStoreInit.Enum(),
 		ScenarioDataStoreInitRequest: &gauge_messages.ScenarioDataStoreInitRequest{}}
-	initResult := executeAndGetStatus(executor.runner, initScenarioDataStoreMessage, executor.writer)
+	initResult := executor.executeHookMessage(initScenarioDataStoreMessage)
 	if initResult.GetFailed() {
 		executor.writer.Warning("Scenario data store didn't get initialized")
 	}
@@ -159,11 +194,75 @@ func (specExecutor *specExecutor) executeScenarios() []*scenarioResult {
 	return scenarioResults
 }
 
+// Scenarios with their own data table are executed once for each row of the table, and the rest only once.
+func (specExecutor *specExecutor) executeScenariosWithDataTables() {
+	for _, scenario := range specExecutor.specification.scenarios {
+		if scenario.isTableDriven() {
+			specExecutor.specResult.addScenarioTableResults(specExecutor.executeScenarioTableRows(scenario))
+		} else {
+			specExecutor.specResult.addScenarioResults([]*scenarioResult{specExecutor.executeScenario(scenario)})
+		}
+	}
+}
+
+func (executor *specExecutor) executeScenarioTableRows(scenario *scenario) []*scenarioResult {
+	scenarioResults := make([]*scenarioResult, 0)
+	for executor.scenarioTableRow = 0; executor.scenarioTableRow < scenario.dataTable.table.getRowCount(); executor.scenarioTableRow++ {
+		scenarioResults = append(scenarioResults, executor.executeScenario(scenario))
+	}
+	executor.scenarioTableRow = 0
+	return scenarioResults
+}
+
 func (executor *specExecutor) executeScenario(scenario *scenario) *scenarioResult {
-	executor.currentExecutionInfo.CurrentScenario = &gauge_messages.ScenarioInfo{Name: proto.String(scenario.heading.value), Tags: getTagValue(scenario.tags), IsFailed: proto.Bool(false)}
-	executor.writer.ScenarioHeading(scenario.heading.value)
+	executor.currentScenario = scenario
+	heading := scenario.resolveHeading(executor.dataTableLookup())
+	if scenario.skip != nil {
+		executor.writer.Warning("Skipping scenario '%s': %s", heading, scenario.skip.reason)
+		return executor.skippedScenarioResult(scenario, heading, scenario.skip.reason)
+	}
+	specFailed := executor.currentExecutionInfo.GetCurrentSpec().GetIsFailed()
+	scenarioResult := executeWithRetries(*maxRetries, func(attempt int) *scenarioResult {
+		if attempt > 0 {
+			executor.writer.Warning("Retrying scenario '%s', attempt %d of %d", heading, attempt, *maxRetries)
+		}
+		return executor.executeScenarioAttempt(scenario, heading)
+	})
+	if scenarioResult.protoScenario.GetFlaky() {
+		executor.currentExecutionInfo.CurrentSpec.IsFailed = proto.Bool(specFailed)
+	}
+	return scenarioResult
+}
 
-	scenarioResult := &scenarioResult{newProtoScenario(scenario)}
+func (executor *specExecutor) skippedScenarioResult(scenario *scenario, heading string, reason string) *scenarioResult {
+	scenarioResult := &scenarioResult{newProtoScenario(scenario, heading)}
+	executor.addAllItemsForScenarioExecution(scenario, scenarioResult)
+	scenarioResult.skip(reason)
+	return scenarioResult
+}
+
+// executeWithRetries re-runs a failed scenario up to maxRetries times. The result of the last
+// attempt is returned, holding the results of the earlier attempts and whether it passed only after retrying.
+func executeWithRetries(maxRetries int, executeAttempt func(attempt int) *scenarioResult) *scenarioResult {
+	previousAttempts := make([]*gauge_messages.ProtoScenario, 0)
+	scenarioResult := executeAttempt(0)
+	for attempt := 1; attempt <= maxRetries && scenarioResult.getFailure(); attempt++ {
+		previousAttempts = append(previousAttempts, scenarioResult.protoScenario)
+		scenarioResult = executeAttempt(attempt)
+	}
+	if len(previousAttempts) > 0 {
+		scenarioResult.protoScenario.PreviousAttempts = previousAttempts
+		scenarioResult.protoScenario.Flaky = proto.Bool(!scenarioResult.getFailure())
+	}
+	return scenarioResult
+}
+
+func (executor *specExecutor) executeScenarioAttempt(scenario *scenario, heading string) *scenarioResult {
+	executor.setTimeouts(scenario)
+	executor.currentExecutionInfo.CurrentScenario = &gauge_messages.ScenarioInfo{Name: proto.String(heading), Tags: getTagValue(scenario.tags), IsFailed: proto.Bool(false)}
+	executor.writer.ScenarioHeading(heading)
+
+	scenarioResult := &scenarioResult{newProtoScenario(scenario, heading)}
 	executor.addAllItemsForScenarioExecution(scenario, scenarioResult)
 	beforeHookExecutionStatus := executor.executeBeforeScenarioHook(scenarioResult)
 	if beforeHookExecutionStatus.GetFailed() {
@@ -174,6 +273,7 @@ func (executor *specExecutor) executeScenario(scenario *scenario) *scenarioResul
 		if !scenarioResult.getFailure() {
 			executor.executeScenarioItems(scenarioResult)
 		}
+		executor.executeTearDownItems(scenarioResult)
 	}
 
 	afterHookExecutionStatus := executor.executeAfterScenarioHook(scenarioResult)
@@ -185,16 +285,23 @@ func (executor *specExecutor) executeScenario(scenario *scenario) *scenarioResul
 func (executor *specExecutor) addAllItemsForScenarioExecution(scenario *scenario, scenarioResult *scenarioResult) {
 	scenarioResult.addContexts(executor.getContextItemsForScenarioExecution(executor.specification))
 	scenarioResult.addItems(executor.resolveItems(scenario.items))
+	scenarioResult.addTearDownSteps(executor.getTearDownItemsForScenarioExecution(executor.specification))
 }
 
 func (executor *specExecutor) getContextItemsForScenarioExecution(specification *specification) []*gauge_messages.ProtoItem {
-	contextSteps := specification.contexts
-	items := make([]item, len(contextSteps))
-	for i, context := range contextSteps {
-		items[i] = context
+	return executor.resolveSteps(specification.contexts)
+}
+
+func (executor *specExecutor) getTearDownItemsForScenarioExecution(specification *specification) []*gauge_messages.ProtoItem {
+	return executor.resolveSteps(specification.tearDownSteps)
+}
+
+func (executor *specExecutor) resolveSteps(steps []*step) []*gauge_messages.ProtoItem {
+	items := make([]item, len(steps))
+	for i, step := range steps {
+		items[i] = step
 	}
-	contextProtoItems := executor.resolveItems(items)
-	return contextProtoItems
+	return executor.resolveItems(items)
 }
 
 func (executor *specExecutor) executeContextItems(scenarioResult *scenarioResult) {
@@ -211,10 +318,21 @@ func (executor *specExecutor) executeScenarioItems(scenarioResult *scenarioResul
 	}
 }
 
+// Teardown steps run even when the scenario has failed or timed out, so the scenario timeout no longer applies to them.
+func (executor *specExecutor) executeTearDownItems(scenarioResult *scenarioResult) {
+	executor.scenarioDeadline = time.Time{}
+	failure := executor.executeItems(scenarioResult.protoScenario.GetTearDownSteps())
+	if failure {
+		scenarioResult.setFailure()
+	}
+}
+
 func (executor *specExecutor) resolveItems(items []item) []*gauge_messages.ProtoItem {
 	protoItems := make([]*gauge_messages.ProtoItem, 0)
 	for _, item := range items {
-		protoItems = append(protoItems, executor.resolveToProtoItem(item))
+		if protoItem := executor.resolveToProtoItem(item); protoItem != nil {
+			protoItems = append(protoItems, protoItem)
+		}
 	}
 	return protoItems
 }
@@ -286,7 +404,11 @@ func updateProtoStepParameters(protoStep *gauge_messages.ProtoStep, parameters [
 }
 
 func (executor *specExecutor) dataTableLookup() *argLookup {
-	return new(argLookup).fromDataTableRow(&executor.specification.dataTable.table, executor.currentTableRow)
+	lookup := new(argLookup).fromDataTableRow(&executor.specification.dataTable.table, executor.currentTableRow)
+	if executor.currentScenario != nil && executor.currentScenario.isTableDriven() {
+		lookup.addDataTableRow(&executor.currentScenario.dataTable.table, executor.scenarioTableRow)
+	}
+	return lookup
 }
 
 func (executor *specExecutor) executeItem(protoItem *gauge_messages.ProtoItem) bool {
@@ -356,12 +478,22 @@ func printStatus(executionResult *gauge_messages.ProtoExecutionResult, writer ex
 }
 
 func (executor *specExecutor) executeStep(protoStep *gauge_messages.ProtoStep) bool {
+	envErr := resolveEnvVariables(protoStep.GetFragments())
 	stepRequest := executor.createStepRequest(protoStep)
 	stepWithResolvedArgs := createStepFromStepRequest(stepRequest)
 	executor.writer.StepStarting(stepWithResolvedArgs)
 
 	protoStepExecResult := &gauge_messages.ProtoStepExecutionResult{}
 	executor.currentExecutionInfo.CurrentStep = &gauge_messages.StepInfo{Step: stepRequest, IsFailed: proto.Bool(false)}
+	if envErr != nil {
+		// The step is not sent to the runner when its parameters could not be resolved
+		protoStepExecResult.ExecutionResult = &gauge_messages.ProtoExecutionResult{Failed: proto.Bool(true), ErrorMessage: proto.String(envErr.Error()), ExecutionTime: proto.Int64(0)}
+		setStepFailure(executor.currentExecutionInfo)
+		printStatus(protoStepExecResult.ExecutionResult, executor.writer)
+		executor.writer.StepFinished(stepWithResolvedArgs, true)
+		protoStep.StepExecutionResult = protoStepExecResult
+		return true
+	}
 
 	beforeHookStatus := executor.executeBeforeStepHook()
 	if beforeHookStatus.GetFailed() {
@@ -371,7 +503,7 @@ func (executor *specExecutor) executeStep(protoStep *gauge_messages.ProtoStep) b
 		printStatus(beforeHookStatus, executor.writer)
 	} else {
 		executeStepMessage := &gauge_messages.Message{MessageType: gauge_messages.Message_ExecuteStep.Enum(), ExecuteStepRequest: stepRequest}
-		stepExecutionStatus := executeAndGetStatus(executor.runner, executeStepMessage, executor.writer)
+		stepExecutionStatus := executor.executeStepMessage(executeStepMessage)
 		if stepExecutionStatus.GetFailed() {
 			setStepFailure(executor.currentExecutionInfo)
 			printStatus(stepExecutionStatus, executor.writer)
@@ -407,14 +539,14 @@ func (executor *specExecutor) executeBeforeStepHook() *gauge_messages.ProtoExecu
 	message := &gauge_messages.Message{MessageType: gauge_messages.Message_StepExecutionStarting.Enum(),
 		StepExecutionStartingRequest: &gauge_messages.StepExecutionStartingRequest{CurrentExecutionInfo: executor.currentExecutionInfo}}
 	executor.pluginHandler.notifyPlugins(message)
-	return executeAndGetStatus(executor.runner, message, executor.writer)
+	return executor.executeHookMessage(message)
 }
 
 func (executor *specExecutor) executeAfterStepHook() *gauge_messages.ProtoExecutionResult {
 	message := &gauge_messages.Message{MessageType: gauge_messages.Message_StepExecutionEnding.Enum(),
 		StepExecutionEndingRequest: &gauge_messages.StepExecutionEndingRequest{CurrentExecutionInfo: executor.currentExecutionInfo}}
 	executor.pluginHandler.notifyPlugins(message)
-	return executeAndGetStatus(executor.runner, message, executor.writer)
+	return executor.executeHookMessage(message)
 }
 
 func (executor *specExecutor) createStepRequest(protoStep *gauge_messages.ProtoStep) *gauge_messages.ExecuteStepRequest {
@@ -428,7 +560,14 @@ func (executor *specExecutor) getCurrentDataTableValueFor(columnName string) str
 }
 
 func executeAndGetStatus(runner *testRunner, message *gauge_messages.Message, writer executionLogger) *gauge_messages.ProtoExecutionResult {
+	if runner.restartError != nil {
+		return runnerLostResult(runner)
+	}
 	response, err := conn.GetResponseForGaugeMessage(message, runner.connection)
+	return getExecutionStatus(response, err, writer)
+}
+
+func getExecutionStatus(response *gauge_messages.Message, err error, writer executionLogger) *gauge_messages.ProtoExecutionResult {
 	if err != nil {
 		return &gauge_messages.ProtoExecutionResult{Failed: proto.Bool(true), ErrorMessage: proto.String(err.Error())}
 	}
@@ -540,3 +679,62 @@ func validateTableRowsRange(start string, end string, rowCount int) (int, int, e
 	}
 	return startRow - 1, endRow - 1, nil
 }
+
+// Returns the indexes of the rows selected by a comma separated list of rows and row ranges. Eg: 1,3,7-9
+func getDataTableRowIndexes(tableRows string, rowCount int) ([]int, error) {
+	selected := make(map[int]bool)
+	for _, rowsRange := range strings.Split(tableRows, ",") {
+		indexes, err := getDataTableRowsRange(strings.TrimSpace(rowsRange), rowCount)
+		if err != nil {
+			return nil, err
+		}
+		for row := indexes.start; row <= indexes.end; row++ {
+			selected[row] = true
+		}
+	}
+	return selectedRows(rowCount, func(row int) bool { return selected[row] }), nil
+}
+
+// Retains the rows whose cells have the given values for all the columns of the filter. Eg: browser=chrome,os=linux
+// A table without one of the columns has no matching rows.
+func filterDataTableRows(table *table, rows []int, tableFilter string) ([]int, error) {
+	columns := make([]string, 0)
+	values := make([]string, 0)
+	for _, condition := range strings.Split(tableFilter, ",") {
+		columnValue := strings.SplitN(condition, "=", 2)
+		if len(columnValue) != 2 || strings.TrimSpace(columnValue[0]) == "" {
+			return nil, errors.New(fmt.Sprintf("Table filter '%s' should be of the form column=value.", strings.TrimSpace(condition)))
+		}
+		columns = append(columns, strings.TrimSpace(columnValue[0]))
+		values = append(values, strings.TrimSpace(columnValue[1]))
+	}
+	filteredRows := make([]int, 0)
+	for _, column := range columns {
+		if !table.headerExists(column) {
+			return filteredRows, nil
+		}
+	}
+	for _, row := range rows {
+		matches := true
+		for i, column := range columns {
+			if strings.TrimSpace(table.get(column)[row].value) != values[i] {
+				matches = false
+				break
+			}
+		}
+		if matches {
+			filteredRows = append(filteredRows, row)
+		}
+	}
+	return filteredRows, nil
+}
+
+func selectedRows(rowCount int, isSelected func(int) bool) []int {
+	rows := make([]int, 0)
+	for row := 0; row < rowCount; row++ {
+		if isSelected(row) {
+			rows = append(rows, row)
+		}
+	}
+	return rows
+}
@@ -19,6 +19,7 @@ package main
 
 import (
 	"github.com/getgauge/gauge/gauge_messages"
+	"github.com/golang/protobuf/proto"
 	. "gopkg.in/check.v1"
 )
 
@@ -40,7 +41,7 @@ func (s *MySuite) TestResolveConceptToProtoConceptItem(c *C) {
 	conceptDictionary.add(concepts, "file.cpt")
 	spec, _ := parser.parse(specText, conceptDictionary)
 
-	specExecutor := newSpecExecutor(spec, nil, nil, nil, indexRange{start: 0, end: 0})
+	specExecutor := newSpecExecutor(spec, nil, nil, nil, []int{0})
 	protoConcept := specExecutor.resolveToProtoConceptItem(*spec.scenarios[0].steps[0]).GetConcept()
 
 	checkConceptParameterValuesInOrder(c, protoConcept, "456", "foo", "9900")
@@ -80,7 +81,7 @@ func (s *MySuite) TestResolveNestedConceptToProtoConceptItem(c *C) {
 	conceptDictionary.add(concepts, "file.cpt")
 	spec, _ := parser.parse(specText, conceptDictionary)
 
-	specExecutor := newSpecExecutor(spec, nil, nil, nil, indexRange{start: 0, end: 0})
+	specExecutor := newSpecExecutor(spec, nil, nil, nil, []int{0})
 	protoConcept := specExecutor.resolveToProtoConceptItem(*spec.scenarios[0].steps[0]).GetConcept()
 	checkConceptParameterValuesInOrder(c, protoConcept, "456", "foo", "9900")
 
@@ -134,7 +135,7 @@ func (s *MySuite) TestResolveToProtoConceptItemWithDataTable(c *C) {
 	conceptDictionary.add(concepts, "file.cpt")
 	spec, _ := parser.parse(specText, conceptDictionary)
 
-	specExecutor := newSpecExecutor(spec, nil, nil, nil, indexRange{start: 0, end: 0})
+	specExecutor := newSpecExecutor(spec, nil, nil, nil, []int{0})
 
 	// For first row
 	specExecutor.currentTableRow = 0
@@ -240,3 +241,179 @@ func (s *MySuite) TestToGetDataTableRowFromInvalidInput(c *C) {
 	_, err = getDataTableRowsRange("", 3)
 	c.Assert(err.Error(), Equals, "Table rows range validation failed.")
 }
+
+func (s *MySuite) TestToGetDataTableRowIndexesFromListOfRowsAndRanges(c *C) {
+	rows, err := getDataTableRowIndexes("1,3,7-9", 10)
+	c.Assert(err, Equals, nil)
+	c.Assert(rows, DeepEquals, []int{0, 2, 6, 7, 8})
+
+	rows, err = getDataTableRowIndexes("4-5, 2,4", 6)
+	c.Assert(err, Equals, nil)
+	c.Assert(rows, DeepEquals, []int{1, 3, 4})
+
+	_, err = getDataTableRowIndexes("1,,3", 6)
+	c.Assert(err.Error(), Equals, "Table rows range validation failed.")
+	_, err = getDataTableRowIndexes("1,7", 6)
+	c.Assert(err.Error(), Equals, "Table rows range validation failed.")
+}
+
+func (s *MySuite) TestFilterDataTableRowsByColumnValues(c *C) {
+	table := new(table)
+	table.addHeaders([]string{"browser", "os"})
+	table.addRowValues([]string{"chrome", "linux"})
+	table.addRowValues([]string{"firefox", "linux"})
+	table.addRowValues([]string{"chrome", "windows"})
+
+	rows, err := filterDataTableRows(table, []int{0, 1, 2}, "browser=chrome")
+	c.Assert(err, Equals, nil)
+	c.Assert(rows, DeepEquals, []int{0, 2})
+
+	rows, err = filterDataTableRows(table, []int{0, 1, 2}, "browser = chrome, os=windows")
+	c.Assert(err, Equals, nil)
+	c.Assert(rows, DeepEquals, []int{2})
+
+	rows, err = filterDataTableRows(table, []int{1}, "browser=chrome")
+	c.Assert(err, Equals, nil)
+	c.Assert(rows, DeepEquals, []int{})
+
+	_, err = filterDataTableRows(table, []int{0, 1, 2}, "chrome")
+	c.Assert(err.Error(), Equals, "Table filter 'chrome' should be of the form column=value.")
+}
+
+func scenarioAttempts(failures ...bool) func(int) *scenarioResult {
+	return func(attempt int) *scenarioResult {
+		return &scenarioResult{&gauge_messages.ProtoScenario{ScenarioHeading: proto.String("scenario"), Failed: proto.Bool(failures[attempt])}}
+	}
+}
+
+func (s *MySuite) TestExecuteWithRetriesDoesNotRetryPassingScenario(c *C) {
+	result := executeWithRetries(2, scenarioAttempts(false))
+
+	c.Assert(result.getFailure(), Equals, false)
+	c.Assert(len(result.protoScenario.GetPreviousAttempts()), Equals, 0)
+	c.Assert(result.protoScenario.Flaky, IsNil)
+}
+
+func (s *MySuite) TestExecuteWithRetriesMarksScenarioPassingAfterRetryAsFlaky(c *C) {
+	result := executeWithRetries(3, scenarioAttempts(true, true, false, false))
+
+	c.Assert(result.getFailure(), Equals, false)
+	c.Assert(result.protoScenario.GetFlaky(), Equals, true)
+	c.Assert(len(result.protoScenario.GetPreviousAttempts()), Equals, 2)
+	c.Assert(result.protoScenario.GetPreviousAttempts()[0].GetFailed(), Equals, true)
+}
+
+func (s *MySuite) TestExecuteWithRetriesStopsAfterMaxRetries(c *C) {
+	result := executeWithRetries(2, scenarioAttempts(true, true, true, false))
+
+	c.Assert(result.getFailure(), Equals, true)
+	c.Assert(result.protoScenario.GetFlaky(), Equals, false)
+	c.Assert(len(result.protoScenario.GetPreviousAttempts()), Equals, 2)
+}
+
+func (s *MySuite) TestResolveStepWithScenarioDataTable(c *C) {
+	specText := SpecBuilder().specHeading("A spec heading").
+		scenarioHeading("First scenario").
+		tableHeader("id", "name").
+		tableHeader("123", "foo").
+		tableHeader("666", "bar").
+		step("create user <id> and <name>").
+		String()
+
+	spec, _ := new(specParser).parse(specText, new(conceptDictionary))
+	specExecutor := newSpecExecutor(spec, nil, nil, nil, []int{0})
+	specExecutor.currentScenario = spec.scenarios[0]
+
+	specExecutor.scenarioTableRow = 1
+	params := getParameters(specExecutor.resolveToProtoStepItem(spec.scenarios[0].steps[0]).GetStep().GetFragments())
+	c.Assert(len(params), Equals, 2)
+	c.Assert(params[0].GetValue(), Equals, "666")
+	c.Assert(params[1].GetValue(), Equals, "bar")
+}
+
+func (s *MySuite) TestScenarioTableResultsAreReportedAsTableDrivenScenario(c *C) {
+	spec := &specification{heading: &heading{value: "A spec heading"}}
+	specResult := newSpecResult(spec)
+	passedRow := &scenarioResult{&gauge_messages.ProtoScenario{ScenarioHeading: proto.String("scenario"), Failed: proto.Bool(false)}}
+	failedRow := &scenarioResult{&gauge_messages.ProtoScenario{ScenarioHeading: proto.String("scenario"), Failed: proto.Bool(true)}}
+
+	specResult.addScenarioTableResults([]*scenarioResult{passedRow, failedRow})
+
+	c.Assert(specResult.isFailed, Equals, true)
+	c.Assert(specResult.scenarioCount, Equals, 1)
+	c.Assert(specResult.scenarioFailedCount, Equals, 1)
+	c.Assert(len(specResult.failedDataTableRows), Equals, 0)
+	c.Assert(specResult.protoSpec.GetIsTableDriven(), Equals, false)
+	c.Assert(specResult.protoSpec.GetItems()[0].GetItemType(), Equals, gauge_messages.ProtoItem_TableDrivenScenario)
+	c.Assert(len(specResult.protoSpec.GetItems()[0].GetTableDrivenScenario().GetScenarios()), Equals, 2)
+}
+
+func (s *MySuite) TestSkippedSpecIsReportedWithAllItsScenariosSkipped(c *C) {
+	specText := SpecBuilder().specHeading("A spec heading").
+		text("@skip: not ready yet").
+		scenarioHeading("First scenario").
+		step("a step").
+		scenarioHeading("Second scenario").
+		step("another step").
+		String()
+
+	spec, _ := new(specParser).parse(specText, new(conceptDictionary))
+	specResult := newSpecExecutor(spec, nil, nil, newSimpleConsoleWriter(), []int{0}).execute()
+
+	c.Assert(specResult.isSkipped, Equals, true)
+	c.Assert(specResult.isFailed, Equals, false)
+	c.Assert(specResult.skipReason, Equals, "not ready yet")
+	c.Assert(specResult.scenarioCount, Equals, 2)
+	c.Assert(specResult.scenarioSkippedCount, Equals, 2)
+	scenario := specResult.protoSpec.GetItems()[0].GetScenario()
+	c.Assert(scenario.GetSkipped(), Equals, true)
+	c.Assert(scenario.GetSkipReason(), Equals, "not ready yet")
+	c.Assert(len(scenario.GetScenarioItems()), Equals, 1)
+}
+
+func (s *MySuite) TestSkippedScenarioIsNotExecuted(c *C) {
+	specText := SpecBuilder().specHeading("A spec heading").
+		scenarioHeading("First scenario").
+		text("@skip: known issue").
+		step("a step").
+		String()
+
+	spec, _ := new(specParser).parse(specText, new(conceptDictionary))
+	executor := newSpecExecutor(spec, nil, nil, newSimpleConsoleWriter(), []int{0})
+	executor.currentExecutionInfo = &gauge_messages.ExecutionInfo{CurrentSpec: &gauge_messages.SpecInfo{IsFailed: proto.Bool(false)}}
+
+	scenarioResult := executor.executeScenario(spec.scenarios[0])
+
+	c.Assert(scenarioResult.getFailure(), Equals, false)
+	c.Assert(scenarioResult.protoScenario.GetSkipped(), Equals, true)
+	c.Assert(scenarioResult.protoScenario.GetSkipReason(), Equals, "known issue")
+	c.Assert(scenarioResult.protoScenario.GetPreHookFailure(), IsNil)
+}
+
+func (s *MySuite) TestSkippedScenariosAreCounted(c *C) {
+	spec := &specification{heading: &heading{value: "A spec heading"}}
+	specResult := newSpecResult(spec)
+	skippedScenario := &scenarioResult{&gauge_messages.ProtoScenario{ScenarioHeading: proto.String("skipped"), Failed: proto.Bool(false), Skipped: proto.Bool(true)}}
+	passedScenario := &scenarioResult{&gauge_messages.ProtoScenario{ScenarioHeading: proto.String("passed"), Failed: proto.Bool(false)}}
+	skippedRow := &scenarioResult{&gauge_messages.ProtoScenario{ScenarioHeading: proto.String("table driven"), Failed: proto.Bool(false), Skipped: proto.Bool(true)}}
+
+	specResult.addScenarioResults([]*scenarioResult{skippedScenario, passedScenario})
+	specResult.addScenarioTableResults([]*scenarioResult{skippedRow, skippedRow})
+
+	c.Assert(specResult.scenarioCount, Equals, 3)
+	c.Assert(specResult.scenarioSkippedCount, Equals, 2)
+	c.Assert(specResult.isFailed, Equals, false)
+}
+
+func (s *MySuite) TestMetadataIsAddedToSpecInfo(c *C) {
+	specText := "---\nowner: qa-team\n---\n# A spec heading\n@skip: not ready yet\n## Scenario\n* a step\n"
+	spec, _ := new(specParser).parse(specText, new(conceptDictionary))
+	executor := newSpecExecutor(spec, nil, nil, newSimpleConsoleWriter(), []int{0})
+
+	executor.execute()
+
+	metadata := executor.currentExecutionInfo.GetCurrentSpec().GetMetadata()
+	c.Assert(len(metadata), Equals, 1)
+	c.Assert(metadata[0].GetKey(), Equals, "owner")
+	c.Assert(metadata[0].GetValue(), Equals, "qa-team")
+}
@@ -35,21 +35,24 @@ type specItemFilter interface {
 }
 
 type scenarioIndexFilterToRetain struct {
-	indexToNotFilter     int
+	indexesToNotFilter   []int
 	currentScenarioIndex int
 }
+type skippedScenarioFilter struct {
+}
+
 type ScenarioFilterBasedOnTags struct {
 	specTags      []string
 	tagExpression string
 }
 
-func newScenarioIndexFilterToRetain(index int) *scenarioIndexFilterToRetain {
-	return &scenarioIndexFilterToRetain{index, 0}
+func newScenarioIndexFilterToRetain(indexes ...int) *scenarioIndexFilterToRetain {
+	return &scenarioIndexFilterToRetain{indexes, 0}
 }
 
 func (filter *scenarioIndexFilterToRetain) filter(item item) bool {
 	if item.kind() == scenarioKind {
-		if filter.currentScenarioIndex != filter.indexToNotFilter {
+		if !filter.isRetained(filter.currentScenarioIndex) {
 			filter.currentScenarioIndex++
 			return true
 		} else {
@@ -60,6 +63,23 @@ func (filter *scenarioIndexFilterToRetain) filter(item item) bool {
 	return false
 }
 
+func (filter *scenarioIndexFilterToRetain) isRetained(index int) bool {
+	for _, indexToNotFilter := range filter.indexesToNotFilter {
+		if index == indexToNotFilter {
+			return true
+		}
+	}
+	return false
+}
+
+func newSkippedScenarioFilter() *skippedScenarioFilter {
+	return &skippedScenarioFilter{}
+}
+
+func (filter *skippedScenarioFilter) filter(item item) bool {
+	return item.kind() == scenarioKind && item.(*scenario).skip != nil
+}
+
 func newScenarioFilterBasedOnTags(specTags []string, tagExp string) *ScenarioFilterBasedOnTags {
 	return &ScenarioFilterBasedOnTags{specTags, tagExp}
 }
@@ -104,6 +104,24 @@ func (s *MySuite) TestScenarioIndexFilterWithWrongScenarioIndex(c *C) {
 	c.Assert(len(spec.scenarios), Equals, 0)
 }
 
+func (s *MySuite) TestScenarioIndexFilterWithMultipleIndexes(c *C) {
+	specText := SpecBuilder().specHeading("spec heading").
+		scenarioHeading("First scenario").
+		step("a step").
+		scenarioHeading("Second scenario").
+		step("second step").
+		scenarioHeading("Third scenario").
+		step("third user").String()
+
+	spec, parseResult := new(specParser).parse(specText, new(conceptDictionary))
+	c.Assert(parseResult.ok, Equals, true)
+
+	spec.filter(newScenarioIndexFilterToRetain(0, 2))
+	c.Assert(len(spec.scenarios), Equals, 2)
+	c.Assert(spec.scenarios[0].heading.value, Equals, "First scenario")
+	c.Assert(spec.scenarios[1].heading.value, Equals, "Third scenario")
+}
+
 func (s *MySuite) TestToEvaluateTagExpressionWithTwoTags(c *C) {
 	filter := &ScenarioFilterBasedOnTags{tagExpression: "tag1 & tag3"}
 	c.Assert(filter.filterTags([]string{"tag1", "tag2"}), Equals, false)
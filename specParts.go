@@ -0,0 +1,232 @@
+// Copyright 2015 ThoughtWorks, Inc.
+
+// This file is part of Gauge.
+
+// Gauge is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+
+// Gauge is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+
+// You should have received a copy of the GNU General Public License
+// along with Gauge.  If not, see <http://www.gnu.org/licenses/>.
+
+package main
+
+import (
+	"github.com/getgauge/gauge/gauge_messages"
+	"sort"
+)
+
+// specPart is a copy of a specification holding one of its scenarios, or one of its data table rows
+// for table driven specs, so that the scenarios of a spec can be spread across the parallel streams.
+// Every part is executed like a spec of its own, running the spec hooks and the context steps.
+type specPart struct {
+	spec      *specification
+	index     int
+	tableRows []int
+	result    *specResult
+}
+
+// specParts maps the spec copies handed to the streams to the part they represent.
+type specParts map[*specification]*specPart
+
+// splitSpecs splits every spec with more than one scenario or data table row into parts.
+func splitSpecs(specs []*specification) ([]*specification, specParts) {
+	parts := make(specParts)
+	specsToExecute := make([]*specification, 0)
+	for _, spec := range specs {
+		specsToExecute = append(specsToExecute, parts.split(spec)...)
+	}
+	return specsToExecute, parts
+}
+
+func (parts specParts) split(spec *specification) []*specification {
+	rowCount := spec.dataTable.table.getRowCount()
+	copies := make([]*specification, 0)
+	if rowCount == 0 {
+		if len(spec.scenarios) <= 1 {
+			return []*specification{spec}
+		}
+		for i := range spec.scenarios {
+			specCopy := spec.getCopy()
+			specCopy.filter(newScenarioIndexFilterToRetain(i))
+			parts[specCopy] = &specPart{spec: spec, index: i, tableRows: []int{}}
+			copies = append(copies, specCopy)
+		}
+		return copies
+	}
+	rows := getDataTableRows(spec)
+	if len(rows) <= 1 {
+		return []*specification{spec}
+	}
+	for i, row := range rows {
+		specCopy := spec.getCopy()
+		parts[specCopy] = &specPart{spec: spec, index: i, tableRows: []int{row}}
+		copies = append(copies, specCopy)
+	}
+	return copies
+}
+
+// scenarioKey identifies the scenario of the item at the given position of the part's result, so that the rows of
+// a table driven scenario are merged together. A scenario part holds only the scenario at its index, while a data
+// table row part holds all the scenarios of the spec in order.
+func (part *specPart) scenarioKey(position int) int {
+	if len(part.tableRows) == 0 {
+		return part.index
+	}
+	return position
+}
+
+func (parts specParts) getDataTableRows(spec *specification) []int {
+	if part, ok := parts[spec]; ok {
+		return part.tableRows
+	}
+	return getDataTableRows(spec)
+}
+
+func (parts specParts) addResult(spec *specification, result *specResult) {
+	if part, ok := parts[spec]; ok {
+		part.result = result
+	}
+}
+
+// mergeResults replaces the results of the parts of a spec with a single result, placed where
+// the result of its first executed part was.
+func (parts specParts) mergeResults(results []*specResult) []*specResult {
+	partsOfSpec := make(map[*specification][]*specPart)
+	specOfResult := make(map[*specResult]*specification)
+	for _, part := range parts {
+		if part.result == nil {
+			continue
+		}
+		partsOfSpec[part.spec] = append(partsOfSpec[part.spec], part)
+		specOfResult[part.result] = part.spec
+	}
+	mergedResults := make([]*specResult, 0)
+	for _, result := range results {
+		spec, isPart := specOfResult[result]
+		if !isPart {
+			mergedResults = append(mergedResults, result)
+			continue
+		}
+		if specParts, ok := partsOfSpec[spec]; ok {
+			mergedResults = append(mergedResults, mergeSpecPartResults(specParts))
+			delete(partsOfSpec, spec)
+		}
+	}
+	return mergedResults
+}
+
+type ByPartIndex []*specPart
+
+func (s ByPartIndex) Len() int {
+	return len(s)
+}
+
+func (s ByPartIndex) Swap(i, j int) {
+	s[i], s[j] = s[j], s[i]
+}
+
+func (s ByPartIndex) Less(i, j int) bool {
+	return s[i].index < s[j].index
+}
+
+func mergeSpecPartResults(parts []*specPart) *specResult {
+	sortedParts := make([]*specPart, len(parts))
+	copy(sortedParts, parts)
+	sort.Sort(ByPartIndex(sortedParts))
+
+	first := sortedParts[0].result.protoSpec
+	merged := &specResult{protoSpec: &gauge_messages.ProtoSpec{
+		SpecHeading:   first.SpecHeading,
+		IsTableDriven: first.IsTableDriven,
+		FileName:      first.FileName,
+		Tags:          first.Tags,
+		HeadingSpan:   first.HeadingSpan,
+		Metadata:      first.Metadata,
+		Items:         make([]*gauge_messages.ProtoItem, 0),
+	}, failedDataTableRows: make([]int32, 0)}
+	if sortedParts[0].result.isSkipped {
+		merged.skip(sortedParts[0].result.skipReason)
+	}
+	for _, item := range first.GetItems() {
+		if item.GetItemType() != gauge_messages.ProtoItem_Scenario && item.GetItemType() != gauge_messages.ProtoItem_TableDrivenScenario {
+			merged.protoSpec.Items = append(merged.protoSpec.Items, item)
+		}
+	}
+
+	tableDrivenScenarios := make(map[int]*gauge_messages.ProtoTableDrivenScenario)
+	for _, part := range sortedParts {
+		result := part.result
+		merged.addExecTime(result.executionTime)
+		merged.flakyScenarioCount += result.flakyScenarioCount
+		if result.isFailed {
+			merged.isFailed = true
+		}
+		if merged.protoSpec.PreHookFailure == nil {
+			merged.protoSpec.PreHookFailure = result.protoSpec.GetPreHookFailure()
+		}
+		if merged.protoSpec.PostHookFailure == nil {
+			merged.protoSpec.PostHookFailure = result.protoSpec.GetPostHookFailure()
+		}
+		if len(result.failedDataTableRows) > 0 {
+			merged.failedDataTableRows = append(merged.failedDataTableRows, int32(part.tableRows[0]))
+		}
+		for position, item := range result.protoSpec.GetItems() {
+			switch item.GetItemType() {
+			case gauge_messages.ProtoItem_Scenario:
+				merged.protoSpec.Items = append(merged.protoSpec.Items, item)
+			case gauge_messages.ProtoItem_TableDrivenScenario:
+				key := part.scenarioKey(position)
+				if tableDrivenScenario, ok := tableDrivenScenarios[key]; ok {
+					tableDrivenScenario.Scenarios = append(tableDrivenScenario.Scenarios, item.GetTableDrivenScenario().GetScenarios()...)
+				} else {
+					tableDrivenScenario := &gauge_messages.ProtoTableDrivenScenario{Scenarios: append([]*gauge_messages.ProtoScenario{}, item.GetTableDrivenScenario().GetScenarios()...)}
+					tableDrivenScenarios[key] = tableDrivenScenario
+					merged.protoSpec.Items = append(merged.protoSpec.Items, &gauge_messages.ProtoItem{ItemType: gauge_messages.ProtoItem_TableDrivenScenario.Enum(), TableDrivenScenario: tableDrivenScenario})
+				}
+			}
+		}
+	}
+	merged.countScenarios()
+	return merged
+}
+
+// Counts the scenarios of a merged result. A table driven scenario counts once, fails if any of its rows failed
+// and is skipped if all of its rows were skipped.
+func (result *specResult) countScenarios() {
+	for _, item := range result.protoSpec.GetItems() {
+		switch item.GetItemType() {
+		case gauge_messages.ProtoItem_Scenario:
+			result.scenarioCount++
+			if item.GetScenario().GetFailed() {
+				result.scenarioFailedCount++
+			}
+			if item.GetScenario().GetSkipped() {
+				result.scenarioSkippedCount++
+			}
+		case gauge_messages.ProtoItem_TableDrivenScenario:
+			result.scenarioCount++
+			skippedRows := 0
+			for _, scenario := range item.GetTableDrivenScenario().GetScenarios() {
+				if scenario.GetSkipped() {
+					skippedRows++
+				}
+			}
+			for _, scenario := range item.GetTableDrivenScenario().GetScenarios() {
+				if scenario.GetFailed() {
+					result.scenarioFailedCount++
+					break
+				}
+			}
+			if skippedRows > 0 && skippedRows == len(item.GetTableDrivenScenario().GetScenarios()) {
+				result.scenarioSkippedCount++
+			}
+		}
+	}
+}
@@ -0,0 +1,179 @@
+// Copyright 2015 ThoughtWorks, Inc.
+
+// This file is part of Gauge.
+
+// Gauge is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+
+// Gauge is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+
+// You should have received a copy of the GNU General Public License
+// along with Gauge.  If not, see <http://www.gnu.org/licenses/>.
+
+package main
+
+import (
+	"github.com/getgauge/gauge/gauge_messages"
+	"github.com/golang/protobuf/proto"
+	. "gopkg.in/check.v1"
+)
+
+func (s *MySuite) TestSplitSpecsCreatesAPartPerScenario(c *C) {
+	specText := SpecBuilder().specHeading("spec heading").
+		scenarioHeading("First scenario").
+		step("a step").
+		scenarioHeading("Second scenario").
+		step("second step").String()
+	spec, parseResult := new(specParser).parse(specText, new(conceptDictionary))
+	c.Assert(parseResult.ok, Equals, true)
+
+	specs, parts := splitSpecs([]*specification{spec})
+
+	c.Assert(len(specs), Equals, 2)
+	c.Assert(len(specs[0].scenarios), Equals, 1)
+	c.Assert(specs[0].scenarios[0].heading.value, Equals, "First scenario")
+	c.Assert(specs[1].scenarios[0].heading.value, Equals, "Second scenario")
+	c.Assert(parts[specs[1]].spec, Equals, spec)
+	c.Assert(parts[specs[1]].index, Equals, 1)
+	c.Assert(len(spec.scenarios), Equals, 2)
+}
+
+func (s *MySuite) TestSplitSpecsCreatesAPartPerDataTableRow(c *C) {
+	specText := SpecBuilder().specHeading("spec heading").
+		tableHeader("id").
+		tableRow("1").
+		tableRow("2").
+		tableRow("3").
+		scenarioHeading("First scenario").
+		step("a step <id>").
+		scenarioHeading("Second scenario").
+		step("second step").String()
+	spec, parseResult := new(specParser).parse(specText, new(conceptDictionary))
+	c.Assert(parseResult.ok, Equals, true)
+
+	specs, parts := splitSpecs([]*specification{spec})
+
+	c.Assert(len(specs), Equals, 3)
+	c.Assert(len(specs[2].scenarios), Equals, 2)
+	c.Assert(parts.getDataTableRows(specs[2]), DeepEquals, []int{2})
+}
+
+func (s *MySuite) TestSplitSpecsDoesNotShareScenariosAndStepsBetweenParts(c *C) {
+	specText := SpecBuilder().specHeading("spec heading").
+		tableHeader("id").
+		tableRow("1").
+		tableRow("2").
+		step("context step").
+		scenarioHeading("First scenario").
+		step("a step <id>").String()
+	spec, parseResult := new(specParser).parse(specText, new(conceptDictionary))
+	c.Assert(parseResult.ok, Equals, true)
+
+	specs, _ := splitSpecs([]*specification{spec})
+
+	c.Assert(len(specs), Equals, 2)
+	c.Assert(specs[0].scenarios[0] == specs[1].scenarios[0], Equals, false)
+	c.Assert(specs[0].scenarios[0].steps[0] == specs[1].scenarios[0].steps[0], Equals, false)
+	c.Assert(specs[0].scenarios[0].items[0] == item(specs[0].scenarios[0].steps[0]), Equals, true)
+	c.Assert(specs[0].contexts[0] == specs[1].contexts[0], Equals, false)
+	c.Assert(specs[0].scenarios[0].steps[0].value, Equals, spec.scenarios[0].steps[0].value)
+}
+
+func (s *MySuite) TestSplitSpecsLeavesSingleScenarioSpecs(c *C) {
+	specText := SpecBuilder().specHeading("spec heading").
+		scenarioHeading("First scenario").
+		step("a step").String()
+	spec, parseResult := new(specParser).parse(specText, new(conceptDictionary))
+	c.Assert(parseResult.ok, Equals, true)
+
+	specs, parts := splitSpecs([]*specification{spec})
+
+	c.Assert(len(specs), Equals, 1)
+	c.Assert(specs[0], Equals, spec)
+	c.Assert(len(parts), Equals, 0)
+}
+
+func newPartResult(items ...*gauge_messages.ProtoItem) *specResult {
+	result := &specResult{protoSpec: &gauge_messages.ProtoSpec{SpecHeading: proto.String("spec heading"), FileName: proto.String("foo.spec"), Items: items}, executionTime: 10}
+	for _, item := range items {
+		if item.GetScenario().GetFailed() {
+			result.isFailed = true
+		}
+	}
+	return result
+}
+
+func (s *MySuite) TestMergeResultsOfScenarioParts(c *C) {
+	spec := &specification{}
+	other := newPartResult()
+	first := newPartResult(newProtoScenarioItem("first", false))
+	second := newPartResult(newProtoScenarioItem("second", true))
+	parts := specParts{&specification{}: &specPart{spec: spec, index: 0, result: first}, &specification{}: &specPart{spec: spec, index: 1, result: second}}
+
+	results := parts.mergeResults([]*specResult{second, other, first})
+
+	c.Assert(len(results), Equals, 2)
+	merged := results[0]
+	c.Assert(results[1], Equals, other)
+	c.Assert(merged.isFailed, Equals, true)
+	c.Assert(merged.scenarioCount, Equals, 2)
+	c.Assert(merged.scenarioFailedCount, Equals, 1)
+	c.Assert(merged.executionTime, Equals, int64(20))
+	c.Assert(merged.protoSpec.GetFileName(), Equals, "foo.spec")
+	c.Assert(merged.protoSpec.GetItems()[0].GetScenario().GetScenarioHeading(), Equals, "first")
+	c.Assert(merged.protoSpec.GetItems()[1].GetScenario().GetScenarioHeading(), Equals, "second")
+}
+
+func (s *MySuite) TestMergeResultsOfDataTableRowParts(c *C) {
+	tableDrivenItem := func(failed bool) *gauge_messages.ProtoItem {
+		scenario := &gauge_messages.ProtoScenario{ScenarioHeading: proto.String("scenario"), Failed: proto.Bool(failed)}
+		return &gauge_messages.ProtoItem{ItemType: gauge_messages.ProtoItem_TableDrivenScenario.Enum(), TableDrivenScenario: &gauge_messages.ProtoTableDrivenScenario{Scenarios: []*gauge_messages.ProtoScenario{scenario}}}
+	}
+	spec := &specification{}
+	firstRow := newPartResult(tableDrivenItem(false))
+	secondRow := newPartResult(tableDrivenItem(true))
+	secondRow.isFailed = true
+	secondRow.failedDataTableRows = []int32{0}
+	parts := specParts{&specification{}: &specPart{spec: spec, index: 0, tableRows: []int{3}, result: firstRow},
+		&specification{}: &specPart{spec: spec, index: 1, tableRows: []int{4}, result: secondRow}}
+
+	results := parts.mergeResults([]*specResult{firstRow, secondRow})
+
+	c.Assert(len(results), Equals, 1)
+	merged := results[0]
+	c.Assert(merged.scenarioCount, Equals, 1)
+	c.Assert(merged.scenarioFailedCount, Equals, 1)
+	c.Assert(merged.failedDataTableRows, DeepEquals, []int32{4})
+	c.Assert(len(merged.protoSpec.GetItems()), Equals, 1)
+	c.Assert(len(merged.protoSpec.GetItems()[0].GetTableDrivenScenario().GetScenarios()), Equals, 2)
+}
+
+func (s *MySuite) TestMergeResultsOfScenarioPartsWithScenarioDataTables(c *C) {
+	tableDrivenItem := func(heading string, failed ...bool) *gauge_messages.ProtoItem {
+		scenarios := make([]*gauge_messages.ProtoScenario, 0)
+		for _, f := range failed {
+			scenarios = append(scenarios, &gauge_messages.ProtoScenario{ScenarioHeading: proto.String(heading), Failed: proto.Bool(f)})
+		}
+		return &gauge_messages.ProtoItem{ItemType: gauge_messages.ProtoItem_TableDrivenScenario.Enum(), TableDrivenScenario: &gauge_messages.ProtoTableDrivenScenario{Scenarios: scenarios}}
+	}
+	spec := &specification{}
+	first := newPartResult(tableDrivenItem("first", false, false))
+	second := newPartResult(tableDrivenItem("second", true, false))
+	parts := specParts{&specification{}: &specPart{spec: spec, index: 0, tableRows: []int{}, result: first},
+		&specification{}: &specPart{spec: spec, index: 1, tableRows: []int{}, result: second}}
+
+	results := parts.mergeResults([]*specResult{first, second})
+
+	c.Assert(len(results), Equals, 1)
+	merged := results[0]
+	c.Assert(merged.scenarioCount, Equals, 2)
+	c.Assert(merged.scenarioFailedCount, Equals, 1)
+	c.Assert(len(merged.protoSpec.GetItems()), Equals, 2)
+	c.Assert(merged.protoSpec.GetItems()[0].GetTableDrivenScenario().GetScenarios()[0].GetScenarioHeading(), Equals, "first")
+	c.Assert(len(merged.protoSpec.GetItems()[1].GetTableDrivenScenario().GetScenarios()), Equals, 2)
+}
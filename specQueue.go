@@ -0,0 +1,52 @@
+// Copyright 2015 ThoughtWorks, Inc.
+
+// This file is part of Gauge.
+
+// Gauge is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+
+// Gauge is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+
+// You should have received a copy of the GNU General Public License
+// along with Gauge.  If not, see <http://www.gnu.org/licenses/>.
+
+package main
+
+import "sync"
+
+// specQueue hands out specs one at a time. Parallel streams share a queue and pick the next spec
+// as soon as their runner is free, so a stream with slow specs does not hold up the others.
+type specQueue struct {
+	specs []*specification
+	mutex sync.Mutex
+}
+
+func newSpecQueue(specs []*specification) *specQueue {
+	return &specQueue{specs: specs}
+}
+
+// Returns the next spec to execute, or nil when the queue is empty.
+func (queue *specQueue) next() *specification {
+	queue.mutex.Lock()
+	defer queue.mutex.Unlock()
+	if len(queue.specs) == 0 {
+		return nil
+	}
+	spec := queue.specs[0]
+	queue.specs = queue.specs[1:]
+	return spec
+}
+
+// Removes and returns all the specs which are yet to be executed.
+func (queue *specQueue) drain() []*specification {
+	queue.mutex.Lock()
+	defer queue.mutex.Unlock()
+	remaining := queue.specs
+	queue.specs = make([]*specification, 0)
+	return remaining
+}
@@ -0,0 +1,69 @@
+// Copyright 2015 ThoughtWorks, Inc.
+
+// This file is part of Gauge.
+
+// Gauge is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+
+// Gauge is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+
+// You should have received a copy of the GNU General Public License
+// along with Gauge.  If not, see <http://www.gnu.org/licenses/>.
+
+package main
+
+import (
+	. "gopkg.in/check.v1"
+	"sync"
+)
+
+func (s *MySuite) TestSpecQueueHandsOutSpecsInOrder(c *C) {
+	specs := createSpecsList(2)
+	queue := newSpecQueue(specs)
+
+	c.Assert(queue.next(), Equals, specs[0])
+	c.Assert(queue.next(), Equals, specs[1])
+	c.Assert(queue.next(), IsNil)
+}
+
+func (s *MySuite) TestSpecQueueDrainReturnsRemainingSpecs(c *C) {
+	specs := createSpecsList(3)
+	queue := newSpecQueue(specs)
+	queue.next()
+
+	remaining := queue.drain()
+
+	c.Assert(len(remaining), Equals, 2)
+	c.Assert(remaining[0], Equals, specs[1])
+	c.Assert(queue.next(), IsNil)
+}
+
+func (s *MySuite) TestSpecQueueHandsOutEachSpecOnceAcrossStreams(c *C) {
+	queue := newSpecQueue(createSpecsList(100))
+	counts := make(chan int, 4)
+	var wg sync.WaitGroup
+	for i := 0; i < 4; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			count := 0
+			for spec := queue.next(); spec != nil; spec = queue.next() {
+				count++
+			}
+			counts <- count
+		}()
+	}
+	wg.Wait()
+	close(counts)
+
+	total := 0
+	for count := range counts {
+		total += count
+	}
+	c.Assert(total, Equals, 100)
+}
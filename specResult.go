@@ -23,26 +23,31 @@ import (
 )
 
 type suiteResult struct {
-	specResults      []*specResult
-	preSuite         *(gauge_messages.ProtoHookFailure)
-	postSuite        *(gauge_messages.ProtoHookFailure)
-	isFailed         bool
-	specsFailedCount int
-	executionTime    int64 //in milliseconds
-	unhandledErrors  []error
-	environment      string
-	tags             string
-	projectName      string
-	timestamp        string
+	specResults       []*specResult
+	preSuite          *(gauge_messages.ProtoHookFailure)
+	postSuite         *(gauge_messages.ProtoHookFailure)
+	isFailed          bool
+	specsFailedCount  int
+	specsSkippedCount int
+	executionTime     int64 //in milliseconds
+	unhandledErrors   []error
+	environment       string
+	tags              string
+	projectName       string
+	timestamp         string
 }
 
 type specResult struct {
-	protoSpec           *gauge_messages.ProtoSpec
-	scenarioFailedCount int
-	scenarioCount       int
-	isFailed            bool
-	failedDataTableRows []int32
-	executionTime       int64
+	protoSpec            *gauge_messages.ProtoSpec
+	scenarioFailedCount  int
+	scenarioCount        int
+	scenarioSkippedCount int
+	isFailed             bool
+	isSkipped            bool
+	skipReason           string
+	failedDataTableRows  []int32
+	executionTime        int64
+	flakyScenarioCount   int
 }
 
 type scenarioResult struct {
@@ -99,6 +104,16 @@ func (scenarioResult *scenarioResult) getFailure() bool {
 	return scenarioResult.protoScenario.GetFailed()
 }
 
+func (scenarioResult *scenarioResult) skip(reason string) {
+	scenarioResult.protoScenario.Skipped = proto.Bool(true)
+	scenarioResult.protoScenario.SkipReason = proto.String(reason)
+}
+
+func (specResult *specResult) skip(reason string) {
+	specResult.isSkipped = true
+	specResult.skipReason = reason
+}
+
 func (specResult *specResult) addSpecItems(resolvedItems []*gauge_messages.ProtoItem) {
 	specResult.protoSpec.Items = append(specResult.protoSpec.Items, resolvedItems...)
 }
@@ -128,6 +143,9 @@ func (suiteResult *suiteResult) addSpecResult(specResult *specResult) {
 		suiteResult.isFailed = true
 		suiteResult.specsFailedCount++
 	}
+	if specResult.isSkipped {
+		suiteResult.specsSkippedCount++
+	}
 	suiteResult.executionTime += specResult.executionTime
 	suiteResult.specResults = append(suiteResult.specResults, specResult)
 
@@ -147,6 +165,12 @@ func (specResult *specResult) addScenarioResults(scenarioResults []*scenarioResu
 			specResult.isFailed = true
 			specResult.scenarioFailedCount++
 		}
+		if scenarioResult.protoScenario.GetFlaky() {
+			specResult.flakyScenarioCount++
+		}
+		if scenarioResult.protoScenario.GetSkipped() {
+			specResult.scenarioSkippedCount++
+		}
 		specResult.addExecTime(scenarioResult.protoScenario.GetExecutionTime())
 		specResult.protoSpec.Items = append(specResult.protoSpec.Items, &gauge_messages.ProtoItem{ItemType: gauge_messages.ProtoItem_Scenario.Enum(), Scenario: scenarioResult.protoScenario})
 	}
@@ -157,28 +181,55 @@ func (specResult *specResult) addTableDrivenScenarioResult(scenarioResults [][](
 	numberOfScenarios := len(scenarioResults[0])
 
 	for scenarioIndex := 0; scenarioIndex < numberOfScenarios; scenarioIndex++ {
-		protoTableDrivenScenario := &gauge_messages.ProtoTableDrivenScenario{Scenarios: make([]*gauge_messages.ProtoScenario, 0)}
-		scenarioFailed := false
-		for rowIndex, eachRow := range scenarioResults {
-			protoScenario := eachRow[scenarioIndex].protoScenario
-			protoTableDrivenScenario.Scenarios = append(protoTableDrivenScenario.GetScenarios(), protoScenario)
-			specResult.addExecTime(protoScenario.GetExecutionTime())
-			if protoScenario.GetFailed() {
-				scenarioFailed = true
-				specResult.failedDataTableRows = append(specResult.failedDataTableRows, int32(rowIndex))
-			}
+		rowResults := make([]*scenarioResult, 0)
+		for _, eachRow := range scenarioResults {
+			rowResults = append(rowResults, eachRow[scenarioIndex])
 		}
-		if scenarioFailed {
-			specResult.scenarioFailedCount++
-			specResult.isFailed = true
-		}
-		protoItem := &gauge_messages.ProtoItem{ItemType: gauge_messages.ProtoItem_TableDrivenScenario.Enum(), TableDrivenScenario: protoTableDrivenScenario}
-		specResult.protoSpec.Items = append(specResult.protoSpec.Items, protoItem)
+		failedRows := specResult.addTableDrivenScenario(rowResults)
+		specResult.failedDataTableRows = append(specResult.failedDataTableRows, failedRows...)
 	}
 	specResult.protoSpec.IsTableDriven = proto.Bool(true)
 	specResult.scenarioCount += numberOfScenarios
 }
 
+// Adds the results of a scenario driven by its own data table, one for each row of the table.
+// The rows of the spec's data table are not involved, so the spec is not marked as table driven.
+func (specResult *specResult) addScenarioTableResults(rowResults []*scenarioResult) {
+	specResult.addTableDrivenScenario(rowResults)
+	specResult.scenarioCount++
+}
+
+// Adds a table driven scenario item holding the result of every row, and returns the indexes of the failed rows.
+func (specResult *specResult) addTableDrivenScenario(rowResults []*scenarioResult) []int32 {
+	protoTableDrivenScenario := &gauge_messages.ProtoTableDrivenScenario{Scenarios: make([]*gauge_messages.ProtoScenario, 0)}
+	failedRows := make([]int32, 0)
+	skippedRows := 0
+	for rowIndex, rowResult := range rowResults {
+		protoScenario := rowResult.protoScenario
+		protoTableDrivenScenario.Scenarios = append(protoTableDrivenScenario.GetScenarios(), protoScenario)
+		specResult.addExecTime(protoScenario.GetExecutionTime())
+		if protoScenario.GetFailed() {
+			failedRows = append(failedRows, int32(rowIndex))
+		}
+		if protoScenario.GetFlaky() {
+			specResult.flakyScenarioCount++
+		}
+		if protoScenario.GetSkipped() {
+			skippedRows++
+		}
+	}
+	if len(failedRows) > 0 {
+		specResult.scenarioFailedCount++
+		specResult.isFailed = true
+	}
+	if len(rowResults) > 0 && skippedRows == len(rowResults) {
+		specResult.scenarioSkippedCount++
+	}
+	protoItem := &gauge_messages.ProtoItem{ItemType: gauge_messages.ProtoItem_TableDrivenScenario.Enum(), TableDrivenScenario: protoTableDrivenScenario}
+	specResult.protoSpec.Items = append(specResult.protoSpec.Items, protoItem)
+	return failedRows
+}
+
 func (specResult *specResult) addExecTime(execTime int64) {
 	specResult.executionTime += execTime
 }
@@ -191,9 +242,14 @@ func (scenarioResult *scenarioResult) addContexts(contextProtoItems []*gauge_mes
 	scenarioResult.protoScenario.Contexts = append(scenarioResult.protoScenario.Contexts, contextProtoItems...)
 }
 
+func (scenarioResult *scenarioResult) addTearDownSteps(tearDownProtoItems []*gauge_messages.ProtoItem) {
+	scenarioResult.protoScenario.TearDownSteps = append(scenarioResult.protoScenario.TearDownSteps, tearDownProtoItems...)
+}
+
 func (scenarioResult *scenarioResult) updateExecutionTime() {
 	scenarioResult.updateExecutionTimeFromItems(scenarioResult.protoScenario.GetContexts())
 	scenarioResult.updateExecutionTimeFromItems(scenarioResult.protoScenario.GetScenarioItems())
+	scenarioResult.updateExecutionTimeFromItems(scenarioResult.protoScenario.GetTearDownSteps())
 }
 
 func (scenarioResult *scenarioResult) updateExecutionTimeFromItems(protoItems []*gauge_messages.ProtoItem) {
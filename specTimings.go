@@ -0,0 +1,152 @@
+// Copyright 2015 ThoughtWorks, Inc.
+
+// This file is part of Gauge.
+
+// Gauge is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+
+// Gauge is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+
+// You should have received a copy of the GNU General Public License
+// along with Gauge.  If not, see <http://www.gnu.org/licenses/>.
+
+package main
+
+import (
+	"encoding/json"
+	"github.com/getgauge/common"
+	"github.com/getgauge/gauge/config"
+	"github.com/getgauge/gauge/logger"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+)
+
+const timingsFileName = "timings.json"
+
+// specTimings holds the execution time in milliseconds of every spec from the previous runs,
+// keyed by the path of the spec file relative to the project root.
+type specTimings map[string]int64
+
+func getTimingsFilePath() string {
+	return filepath.Join(config.ProjectRoot, dotGaugeDirectoryName, timingsFileName)
+}
+
+// Returns the recorded timings, or no timings if the file does not exist or cannot be read.
+func loadSpecTimings(timingsFile string) specTimings {
+	timings := make(specTimings)
+	if !common.FileExists(timingsFile) {
+		return timings
+	}
+	contents, err := common.ReadFileContents(timingsFile)
+	if err != nil {
+		logger.Log.Warning("Failed to read %s. %s\n", timingsFile, err.Error())
+		return timings
+	}
+	if err := json.Unmarshal([]byte(contents), &timings); err != nil {
+		logger.Log.Warning("Failed to read %s. %s\n", timingsFile, err.Error())
+		return make(specTimings)
+	}
+	return timings
+}
+
+func saveSpecTimings(result *suiteResult) {
+	timingsFile := getTimingsFilePath()
+	timings := loadSpecTimings(timingsFile)
+	timings.update(result)
+	if err := timings.write(timingsFile); err != nil {
+		logger.Log.Warning("Failed to save the spec execution times. %s\n", err.Error())
+	}
+}
+
+// Records the execution times of the specs in the result, keeping the times of the specs which were not executed.
+func (timings specTimings) update(result *suiteResult) {
+	for _, specResult := range result.specResults {
+		if specResult.isSkipped {
+			continue
+		}
+		timings[timingsKey(specResult.protoSpec.GetFileName())] = specResult.executionTime
+	}
+}
+
+func (timings specTimings) write(timingsFile string) error {
+	contents, err := json.MarshalIndent(timings, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(timingsFile), common.NewDirectoryPermissions); err != nil {
+		return err
+	}
+	return ioutil.WriteFile(timingsFile, contents, common.NewFilePermissions)
+}
+
+func timingsKey(fileName string) string {
+	return filepath.ToSlash(relativeToProjectRoot(fileName))
+}
+
+// distributeSpecs splits the specs into groups of roughly equal expected duration. Each spec with a recorded time
+// goes to the group with the least total time so far, longest first. Specs with no recorded time are spread by count.
+func (timings specTimings) distributeSpecs(specs []*specification, distributions int) []*specCollection {
+	if distributions > len(specs) {
+		distributions = len(specs)
+	}
+	specCollections := make([]*specCollection, distributions)
+	for i := range specCollections {
+		specCollections[i] = &specCollection{specs: make([]*specification, 0)}
+	}
+	specsWithTimings := make([]*specification, 0)
+	specsWithoutTimings := make([]*specification, 0)
+	for _, spec := range specs {
+		if _, ok := timings[timingsKey(spec.fileName)]; ok {
+			specsWithTimings = append(specsWithTimings, spec)
+		} else {
+			specsWithoutTimings = append(specsWithoutTimings, spec)
+		}
+	}
+
+	sort.Sort(byDecreasingTime{specsWithTimings, timings})
+	durations := make([]int64, distributions)
+	for _, spec := range specsWithTimings {
+		shortest := 0
+		for i := range durations {
+			if durations[i] < durations[shortest] {
+				shortest = i
+			}
+		}
+		specCollections[shortest].specs = append(specCollections[shortest].specs, spec)
+		durations[shortest] += timings[timingsKey(spec.fileName)]
+	}
+
+	sort.Sort(ByFileName(specsWithoutTimings))
+	for i, spec := range specsWithoutTimings {
+		specCollections[i%distributions].specs = append(specCollections[i%distributions].specs, spec)
+	}
+	return specCollections
+}
+
+type byDecreasingTime struct {
+	specs   []*specification
+	timings specTimings
+}
+
+func (s byDecreasingTime) Len() int {
+	return len(s.specs)
+}
+
+func (s byDecreasingTime) Swap(i, j int) {
+	s.specs[i], s.specs[j] = s.specs[j], s.specs[i]
+}
+
+func (s byDecreasingTime) Less(i, j int) bool {
+	iTime, jTime := s.timings[timingsKey(s.specs[i].fileName)], s.timings[timingsKey(s.specs[j].fileName)]
+	if iTime == jTime {
+		return s.specs[i].fileName < s.specs[j].fileName
+	}
+	return iTime > jTime
+}
@@ -0,0 +1,112 @@
+// Copyright 2015 ThoughtWorks, Inc.
+
+// This file is part of Gauge.
+
+// Gauge is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+
+// Gauge is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+
+// You should have received a copy of the GNU General Public License
+// along with Gauge.  If not, see <http://www.gnu.org/licenses/>.
+
+package main
+
+import (
+	"github.com/getgauge/gauge/gauge_messages"
+	"github.com/golang/protobuf/proto"
+	. "gopkg.in/check.v1"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+)
+
+func specsWithFileNames(fileNames ...string) []*specification {
+	specs := make([]*specification, 0)
+	for _, fileName := range fileNames {
+		specs = append(specs, &specification{fileName: fileName})
+	}
+	return specs
+}
+
+func totalTime(timings specTimings, collection *specCollection) int64 {
+	var total int64
+	for _, spec := range collection.specs {
+		total += timings[spec.fileName]
+	}
+	return total
+}
+
+func (s *MySuite) TestDistributeSpecsBalancesGroupsByRecordedTime(c *C) {
+	timings := specTimings{"a.spec": 100, "b.spec": 70, "c.spec": 30, "d.spec": 20, "e.spec": 20}
+
+	specCollections := timings.distributeSpecs(specsWithFileNames("a.spec", "b.spec", "c.spec", "d.spec", "e.spec"), 2)
+
+	c.Assert(len(specCollections), Equals, 2)
+	c.Assert(totalTime(timings, specCollections[0]), Equals, int64(120))
+	c.Assert(totalTime(timings, specCollections[1]), Equals, int64(120))
+}
+
+func (s *MySuite) TestDistributeSpecsSpreadsSpecsWithoutHistoryByCount(c *C) {
+	timings := specTimings{"a.spec": 100}
+
+	specCollections := timings.distributeSpecs(specsWithFileNames("d.spec", "a.spec", "b.spec", "c.spec"), 2)
+
+	c.Assert(len(specCollections[0].specs), Equals, 3)
+	c.Assert(specCollections[0].specs[0].fileName, Equals, "a.spec")
+	c.Assert(specCollections[0].specs[1].fileName, Equals, "b.spec")
+	c.Assert(specCollections[0].specs[2].fileName, Equals, "d.spec")
+	c.Assert(len(specCollections[1].specs), Equals, 1)
+	c.Assert(specCollections[1].specs[0].fileName, Equals, "c.spec")
+}
+
+func (s *MySuite) TestDistributeSpecsGivesSameGroupsEverytime(c *C) {
+	timings := specTimings{"a.spec": 10, "b.spec": 10, "c.spec": 10}
+
+	first := timings.distributeSpecs(specsWithFileNames("c.spec", "a.spec", "b.spec"), 3)
+	second := timings.distributeSpecs(specsWithFileNames("b.spec", "c.spec", "a.spec"), 3)
+
+	for i := range first {
+		c.Assert(first[i].specs[0].fileName, Equals, second[i].specs[0].fileName)
+	}
+}
+
+func (s *MySuite) TestSpecTimingsAreUpdatedAndWrittenBack(c *C) {
+	dir, err := ioutil.TempDir("", "gauge_timings")
+	c.Assert(err, IsNil)
+	defer os.RemoveAll(dir)
+	timingsFile := filepath.Join(dir, dotGaugeDirectoryName, timingsFileName)
+	result := newSuiteResult()
+	result.addSpecResult(&specResult{protoSpec: &gauge_messages.ProtoSpec{FileName: proto.String("b.spec")}, executionTime: 20})
+	result.addSpecResult(&specResult{protoSpec: &gauge_messages.ProtoSpec{FileName: proto.String("c.spec")}, executionTime: 0, isSkipped: true})
+
+	timings := specTimings{"a.spec": 10, "b.spec": 5}
+	timings.update(result)
+	c.Assert(timings.write(timingsFile), IsNil)
+
+	c.Assert(loadSpecTimings(timingsFile), DeepEquals, specTimings{"a.spec": 10, "b.spec": 20})
+}
+
+func (s *MySuite) TestLoadSpecTimingsWithoutFileGivesNoTimings(c *C) {
+	c.Assert(len(loadSpecTimings(filepath.Join(os.TempDir(), "no_such_dir", timingsFileName))), Equals, 0)
+}
+
+func (s *MySuite) TestGroupFilterBalancesGroupsByTheGivenTimingsFile(c *C) {
+	dir, err := ioutil.TempDir("", "gauge_timings")
+	c.Assert(err, IsNil)
+	defer os.RemoveAll(dir)
+	timingsFile := filepath.Join(dir, timingsFileName)
+	timings := specTimings{"a.spec": 100, "b.spec": 50, "c.spec": 50}
+	c.Assert(timings.write(timingsFile), IsNil)
+
+	groupFilter := &specsGroupFilter{1, 2, timingsFile}
+	specs := groupFilter.filter(specsWithFileNames("a.spec", "b.spec", "c.spec"))
+
+	c.Assert(len(specs), Equals, 1)
+	c.Assert(specs[0].fileName, Equals, "a.spec")
+}
@@ -22,15 +22,18 @@ import (
 	"github.com/getgauge/gauge/gauge_messages"
 	"github.com/golang/protobuf/proto"
 	"regexp"
+	"sort"
 	"strings"
 )
 
 type scenario struct {
-	heading  *heading
-	steps    []*step
-	comments []*comment
-	tags     *tags
-	items    []item
+	heading   *heading
+	steps     []*step
+	comments  []*comment
+	tags      *tags
+	dataTable dataTable
+	skip      *skip
+	items     []item
 }
 
 type argType string
@@ -49,6 +52,20 @@ type stepArg struct {
 	value   string
 	argType argType
 	table   table
+	span    span
+	source  string
+}
+
+//name of a special param with the path of the file that was actually loaded for it
+func (stepArg *stepArg) resolvedName() string {
+	if stepArg.source == "" {
+		return stepArg.name
+	}
+	prefixEnd := strings.Index(stepArg.name, ":")
+	if prefixEnd == -1 {
+		return stepArg.source
+	}
+	return fmt.Sprintf("%s:%s", strings.TrimSpace(stepArg.name[:prefixEnd]), stepArg.source)
 }
 
 func (stepArg *stepArg) String() string {
@@ -87,6 +104,7 @@ type step struct {
 	hasInlineTable bool
 	items          []item
 	preComments    []*comment
+	span           span
 }
 
 func (step *step) getArg(name string) *stepArg {
@@ -191,14 +209,18 @@ func createStepArgsFromProtoArguments(parameters []*gauge_messages.Parameter) []
 }
 
 type specification struct {
-	heading   *heading
-	scenarios []*scenario
-	comments  []*comment
-	dataTable dataTable
-	contexts  []*step
-	fileName  string
-	tags      *tags
-	items     []item
+	heading       *heading
+	scenarios     []*scenario
+	comments      []*comment
+	dataTable     dataTable
+	contexts      []*step
+	tearDownSteps []*step
+	fileName      string
+	tags          *tags
+	skip          *skip
+	metadata      *metadata
+	items         []item
+	dataTableRows string
 }
 
 type item interface {
@@ -216,6 +238,7 @@ type heading struct {
 	value       string
 	lineNo      int
 	headingType headingType
+	span        span
 }
 
 type comment struct {
@@ -224,7 +247,31 @@ type comment struct {
 }
 
 type tags struct {
-	values []string
+	values     []string
+	span       span
+	valueSpans []span
+}
+
+type metadata struct {
+	entries []*metadataEntry
+	lineNo  int
+	span    span
+}
+
+type metadataEntry struct {
+	key   string
+	value string
+}
+
+type skip struct {
+	reason string
+	lineNo int
+	span   span
+}
+
+type tearDown struct {
+	value  string
+	lineNo int
 }
 
 type warning struct {
@@ -234,6 +281,7 @@ type warning struct {
 
 type parseResult struct {
 	error    *parseError
+	errors   []*parseError
 	warnings []*warning
 	ok       bool
 	fileName string
@@ -251,9 +299,15 @@ func converterFn(predicate func(token *token, state *int) bool, apply func(token
 }
 
 func (specParser *specParser) createSpecification(tokens []*token, conceptDictionary *conceptDictionary) (*specification, *parseResult) {
+	specification, finalResult := specParser.convertTokens(tokens, conceptDictionary)
+	return specParser.validatedSpecification(specification, finalResult)
+}
+
+//every token is converted even after an error, so that all the errors in the spec are collected
+func (specParser *specParser) convertTokens(tokens []*token, conceptDictionary *conceptDictionary) (*specification, *parseResult) {
 	specParser.conceptDictionary = conceptDictionary
 	converters := specParser.initializeConverters()
-	specification := &specification{}
+	specification := &specification{fileName: specParser.fileName}
 	finalResult := &parseResult{}
 	state := initial
 
@@ -262,9 +316,8 @@ func (specParser *specParser) createSpecification(tokens []*token, conceptDictio
 			result := converter(token, &state, specification)
 			if !result.ok {
 				if result.error != nil {
-					finalResult.ok = false
-					finalResult.error = result.error
-					return nil, finalResult
+					finalResult.addErrors(result.error)
+					break
 				}
 			}
 			if result.warnings != nil {
@@ -277,10 +330,17 @@ func (specParser *specParser) createSpecification(tokens []*token, conceptDictio
 	}
 
 	specification.processConceptStepsFrom(conceptDictionary)
-	validationError := specParser.validateSpec(specification)
-	if validationError != nil {
-		finalResult.ok = false
-		finalResult.error = validationError
+	return specification, finalResult
+}
+
+//the spec is validated only when it has no other errors, as an incompletely parsed spec would report misleading validation errors
+func (specParser *specParser) validatedSpecification(specification *specification, finalResult *parseResult) (*specification, *parseResult) {
+	if len(finalResult.errors) == 0 {
+		if validationError := specParser.validateSpec(specification); validationError != nil {
+			finalResult.addErrors(validationError)
+		}
+	}
+	if len(finalResult.errors) > 0 {
 		return nil, finalResult
 	}
 	finalResult.ok = true
@@ -295,7 +355,7 @@ func (specParser *specParser) initializeConverters() []func(*token, *int, *speci
 			return parseResult{ok: false, error: &parseError{token.lineNo, "Parse error: Multiple spec headings found in same file", token.lineText}}
 		}
 
-		spec.addHeading(&heading{lineNo: token.lineNo, value: token.value})
+		spec.addHeading(&heading{lineNo: token.lineNo, value: token.value, span: token.span})
 		addStates(state, specScope)
 		return parseResult{ok: true}
 	})
@@ -306,13 +366,16 @@ func (specParser *specParser) initializeConverters() []func(*token, *int, *speci
 		if spec.heading == nil {
 			return parseResult{ok: false, error: &parseError{token.lineNo, "Parse error: Scenario should be defined after the spec heading", token.lineText}}
 		}
+		if isInState(*state, tearDownScope) {
+			return parseResult{ok: false, error: &parseError{token.lineNo, "Parse error: Scenario should be defined before the teardown steps", token.lineText}}
+		}
 		for _, scenario := range spec.scenarios {
 			if strings.ToLower(scenario.heading.value) == strings.ToLower(token.value) {
 				return parseResult{ok: false, error: &parseError{token.lineNo, "Parse error: Duplicate scenario definitions are not allowed in the same specification", token.lineText}}
 			}
 		}
 		scenario := &scenario{}
-		scenario.addHeading(&heading{value: token.value, lineNo: token.lineNo})
+		scenario.addHeading(&heading{value: token.value, lineNo: token.lineNo, span: token.span})
 		spec.addScenario(scenario)
 
 		retainStates(state, specScope)
@@ -324,7 +387,7 @@ func (specParser *specParser) initializeConverters() []func(*token, *int, *speci
 		return token.kind == stepKind && isInState(*state, scenarioScope)
 	}, func(token *token, spec *specification, state *int) parseResult {
 		latestScenario := spec.latestScenario()
-		stepToAdd, parseDetails := spec.createStep(token)
+		stepToAdd, parseDetails := spec.createStepUsingLookup(token, spec.scenarioDataTableLookup(latestScenario))
 		if parseDetails != nil && parseDetails.error != nil {
 			return parseResult{error: parseDetails.error, ok: false, warnings: parseDetails.warnings}
 		}
@@ -338,7 +401,7 @@ func (specParser *specParser) initializeConverters() []func(*token, *int, *speci
 	})
 
 	contextConverter := converterFn(func(token *token, state *int) bool {
-		return token.kind == stepKind && !isInState(*state, scenarioScope) && isInState(*state, specScope)
+		return token.kind == stepKind && !isInState(*state, scenarioScope) && !isInState(*state, tearDownScope) && isInState(*state, specScope)
 	}, func(token *token, spec *specification, state *int) parseResult {
 		stepToAdd, parseDetails := spec.createStep(token)
 		if parseDetails != nil && parseDetails.error != nil {
@@ -353,6 +416,56 @@ func (specParser *specParser) initializeConverters() []func(*token, *int, *speci
 		return parseResult{ok: true, warnings: parseDetails.warnings}
 	})
 
+	tearDownConverter := converterFn(func(token *token, state *int) bool {
+		return token.kind == tearDownKind
+	}, func(token *token, spec *specification, state *int) parseResult {
+		if spec.heading == nil {
+			return parseResult{ok: false, error: &parseError{token.lineNo, "Parse error: Teardown should be defined after the spec heading", token.lineText}}
+		}
+		if isInState(*state, tearDownScope) {
+			value := "Multiple teardown separators present, ignoring separator"
+			spec.addComment(&comment{token.lineText, token.lineNo})
+			return parseResult{ok: false, warnings: []*warning{&warning{value, token.lineNo}}}
+		}
+		spec.addTearDown(&tearDown{value: token.value, lineNo: token.lineNo})
+		retainStates(state, specScope)
+		addStates(state, tearDownScope)
+		return parseResult{ok: true}
+	})
+
+	tearDownStepConverter := converterFn(func(token *token, state *int) bool {
+		return token.kind == stepKind && isInState(*state, tearDownScope)
+	}, func(token *token, spec *specification, state *int) parseResult {
+		stepToAdd, parseDetails := spec.createStep(token)
+		if parseDetails != nil && parseDetails.error != nil {
+			return parseResult{error: parseDetails.error, ok: false, warnings: parseDetails.warnings}
+		}
+		spec.addTearDownStep(stepToAdd)
+		retainStates(state, specScope, tearDownScope)
+		addStates(state, stepScope)
+		if parseDetails.warnings != nil {
+			return parseResult{ok: false, warnings: parseDetails.warnings}
+		}
+		return parseResult{ok: true, warnings: parseDetails.warnings}
+	})
+
+	textBlockConverter := converterFn(func(token *token, state *int) bool {
+		return token.kind == textBlockKind
+	}, func(token *token, spec *specification, state *int) parseResult {
+		if isInState(*state, tearDownScope) && isInState(*state, stepScope) {
+			addTextBlock(spec.latestTearDownStep(), token)
+		} else if isInState(*state, stepScope) {
+			addTextBlock(spec.latestScenario().latestStep(), token)
+		} else if isInState(*state, contextScope) {
+			addTextBlock(spec.latestContext(), token)
+		} else {
+			value := "Text block not associated with a step, ignoring text block"
+			spec.addComment(&comment{formatTextBlock(token.lineText, token.value), token.lineNo})
+			return parseResult{ok: false, warnings: []*warning{&warning{value, token.lineNo}}}
+		}
+		return parseResult{ok: true}
+	})
+
 	commentConverter := converterFn(func(token *token, state *int) bool {
 		return token.kind == commentKind
 	}, func(token *token, spec *specification, state *int) parseResult {
@@ -362,7 +475,7 @@ func (specParser *specParser) initializeConverters() []func(*token, *int, *speci
 		} else {
 			spec.addComment(comment)
 		}
-		retainStates(state, specScope, scenarioScope)
+		retainStates(state, specScope, scenarioScope, tearDownScope)
 		addStates(state, commentScope)
 		return parseResult{ok: true}
 	})
@@ -370,8 +483,15 @@ func (specParser *specParser) initializeConverters() []func(*token, *int, *speci
 	keywordConverter := converterFn(func(token *token, state *int) bool {
 		return token.kind == dataTableKind
 	}, func(token *token, spec *specification, state *int) parseResult {
-		resolvedArg, _ := newSpecialTypeResolver().resolve(token.value)
-		if isInState(*state, specScope) && !spec.dataTable.isInitialized() {
+		resolvedArg, _ := newSpecialTypeResolverFor(spec.fileName).resolve(dataTableParam(token.value))
+		if isInState(*state, scenarioScope) && spec.latestScenario().canAddDataTable() && !spec.dataTable.isInitialized() {
+			externalTable := &dataTable{}
+			externalTable.table = resolvedArg.table
+			externalTable.lineNo = token.lineNo
+			externalTable.value = token.value
+			externalTable.isExternal = true
+			spec.latestScenario().addExternalDataTable(externalTable)
+		} else if isInState(*state, specScope) && !spec.dataTable.isInitialized() {
 			externalTable := &dataTable{}
 			externalTable.table = resolvedArg.table
 			externalTable.lineNo = token.lineNo
@@ -395,32 +515,46 @@ func (specParser *specParser) initializeConverters() []func(*token, *int, *speci
 	tableHeaderConverter := converterFn(func(token *token, state *int) bool {
 		return token.kind == tableHeader && isInState(*state, specScope)
 	}, func(token *token, spec *specification, state *int) parseResult {
-		if isInState(*state, stepScope) {
+		result := parseResult{ok: true}
+		if isInState(*state, tearDownScope) && isInState(*state, stepScope) {
+			result = addInlineTableHeader(spec.latestTearDownStep(), token, new(argLookup).fromDataTable(&spec.dataTable.table))
+		} else if isInState(*state, tearDownScope) {
+			value := "Table not associated with a step, ignoring table"
+			spec.addComment(&comment{token.lineText, token.lineNo})
+			return parseResult{ok: false, warnings: []*warning{&warning{value, token.lineNo}}}
+		} else if isInState(*state, stepScope) {
 			latestScenario := spec.latestScenario()
 			latestStep := latestScenario.latestStep()
-			addInlineTableHeader(latestStep, token)
+			result = addInlineTableHeader(latestStep, token, spec.scenarioDataTableLookup(latestScenario))
 		} else if isInState(*state, contextScope) {
 			latestContext := spec.latestContext()
-			addInlineTableHeader(latestContext, token)
+			result = addInlineTableHeader(latestContext, token, new(argLookup).fromDataTable(&spec.dataTable.table))
 		} else if !isInState(*state, scenarioScope) {
 			if !spec.dataTable.table.isInitialized() {
-				dataTable := &table{}
-				dataTable.lineNo = token.lineNo
-				dataTable.addHeaders(token.args)
+				dataTable := newTableWithHeaderRow(token)
 				spec.addDataTable(dataTable)
 			} else {
 				value := "Multiple data table present, ignoring table"
 				spec.addComment(&comment{token.lineText, token.lineNo})
 				return parseResult{ok: false, warnings: []*warning{&warning{value, token.lineNo}}}
 			}
+		} else if spec.latestScenario().canAddDataTable() {
+			if !spec.dataTable.table.isInitialized() {
+				dataTable := newTableWithHeaderRow(token)
+				spec.latestScenario().addDataTable(dataTable)
+			} else {
+				value := "Multiple data table present, ignoring table"
+				spec.latestScenario().addComment(&comment{token.lineText, token.lineNo})
+				return parseResult{ok: false, warnings: []*warning{&warning{value, token.lineNo}}}
+			}
 		} else {
 			value := "Table not associated with a step, ignoring table"
 			spec.latestScenario().addComment(&comment{token.lineText, token.lineNo})
 			return parseResult{ok: false, warnings: []*warning{&warning{value, token.lineNo}}}
 		}
-		retainStates(state, specScope, scenarioScope, stepScope, contextScope)
+		retainStates(state, specScope, scenarioScope, stepScope, contextScope, tearDownScope)
 		addStates(state, tableScope)
-		return parseResult{ok: true}
+		return result
 	})
 
 	tableRowConverter := converterFn(func(token *token, state *int) bool {
@@ -437,26 +571,73 @@ func (specParser *specParser) initializeConverters() []func(*token, *int, *speci
 		} else if areUnderlined(token.args) {
 			// skip table separator
 			result = parseResult{ok: true}
+		} else if isInState(*state, tearDownScope) {
+			result = addInlineTableRow(spec.latestTearDownStep(), token, new(argLookup).fromDataTable(&spec.dataTable.table))
 		} else if isInState(*state, stepScope) {
 			latestScenario := spec.latestScenario()
 			latestStep := latestScenario.latestStep()
-			result = addInlineTableRow(latestStep, token, new(argLookup).fromDataTable(&spec.dataTable.table))
+			result = addInlineTableRow(latestStep, token, spec.scenarioDataTableLookup(latestScenario))
 		} else if isInState(*state, contextScope) {
 			latestContext := spec.latestContext()
 			result = addInlineTableRow(latestContext, token, new(argLookup).fromDataTable(&spec.dataTable.table))
+		} else if isInState(*state, scenarioScope) {
+			spec.latestScenario().dataTable.table.addRowValuesWithSpans(token.args, token.argSpans)
+			result = parseResult{ok: true}
 		} else {
 			//todo validate datatable rows also
-			spec.dataTable.table.addRowValues(token.args)
+			spec.dataTable.table.addRowValuesWithSpans(token.args, token.argSpans)
 			result = parseResult{ok: true}
 		}
-		retainStates(state, specScope, scenarioScope, stepScope, contextScope, tableScope)
+		retainStates(state, specScope, scenarioScope, stepScope, contextScope, tearDownScope, tableScope)
 		return result
 	})
 
+	metadataConverter := converterFn(func(token *token, state *int) bool {
+		return token.kind == metadataKind
+	}, func(token *token, spec *specification, state *int) parseResult {
+		specMetadata := &metadata{lineNo: token.lineNo, span: token.span}
+		for i := 0; i+1 < len(token.args); i += 2 {
+			specMetadata.entries = append(specMetadata.entries, &metadataEntry{key: token.args[i], value: token.args[i+1]})
+		}
+		spec.addMetadata(specMetadata)
+		return parseResult{ok: true}
+	})
+
+	skipConverter := converterFn(func(token *token, state *int) bool {
+		return token.kind == skipKind
+	}, func(token *token, spec *specification, state *int) parseResult {
+		if spec.heading == nil {
+			return parseResult{ok: false, error: &parseError{token.lineNo, "Parse error: Skip marker should be defined after the spec heading", token.lineText}}
+		}
+		skipMarker := &skip{reason: token.value, lineNo: token.lineNo, span: token.span}
+		if isInState(*state, tearDownScope) {
+			value := "Skip marker not associated with a spec or scenario, ignoring marker"
+			spec.addComment(&comment{token.lineText, token.lineNo})
+			return parseResult{ok: false, warnings: []*warning{&warning{value, token.lineNo}}}
+		} else if isInState(*state, scenarioScope) {
+			latestScenario := spec.latestScenario()
+			if latestScenario.skip != nil {
+				value := "Multiple skip markers present, ignoring marker"
+				latestScenario.addComment(&comment{token.lineText, token.lineNo})
+				return parseResult{ok: false, warnings: []*warning{&warning{value, token.lineNo}}}
+			}
+			latestScenario.addSkip(skipMarker)
+		} else {
+			if spec.skip != nil {
+				value := "Multiple skip markers present, ignoring marker"
+				spec.addComment(&comment{token.lineText, token.lineNo})
+				return parseResult{ok: false, warnings: []*warning{&warning{value, token.lineNo}}}
+			}
+			spec.addSkip(skipMarker)
+		}
+		retainStates(state, specScope, scenarioScope)
+		return parseResult{ok: true}
+	})
+
 	tagConverter := converterFn(func(token *token, state *int) bool {
 		return (token.kind == tagKind)
 	}, func(token *token, spec *specification, state *int) parseResult {
-		tags := &tags{values: token.args}
+		tags := &tags{values: token.args, span: token.span, valueSpans: token.argSpans}
 		if isInState(*state, scenarioScope) {
 			spec.latestScenario().addTags(tags)
 		} else {
@@ -466,7 +647,7 @@ func (specParser *specParser) initializeConverters() []func(*token, *int, *speci
 	})
 
 	converter := []func(*token, *int, *specification) parseResult{
-		specConverter, scenarioConverter, stepConverter, contextConverter, commentConverter, tableHeaderConverter, tableRowConverter, tagConverter, keywordConverter,
+		specConverter, scenarioConverter, stepConverter, contextConverter, tearDownConverter, tearDownStepConverter, textBlockConverter, commentConverter, tableHeaderConverter, tableRowConverter, tagConverter, skipConverter, metadataConverter, keywordConverter,
 	}
 
 	return converter
@@ -482,12 +663,19 @@ func (spec *specification) createStep(stepToken *token) (*step, *parseDetailResu
 	return stepToAdd, parseDetails
 }
 
+// Steps of a scenario can refer to the columns of the spec's data table and of the scenario's own data table
+func (spec *specification) scenarioDataTableLookup(scenario *scenario) *argLookup {
+	lookup := new(argLookup).fromDataTable(&spec.dataTable.table)
+	lookup.addDataTableHeaders(&scenario.dataTable.table)
+	return lookup
+}
+
 func (spec *specification) createStepUsingLookup(stepToken *token, lookup *argLookup) (*step, *parseDetailResult) {
 	stepValue, argsType := extractStepValueAndParameterTypes(stepToken.value)
 	if argsType != nil && len(argsType) != len(stepToken.args) {
 		return nil, &parseDetailResult{error: &parseError{stepToken.lineNo, "Step text should not have '{static}' or '{dynamic}' or '{special}'", stepToken.lineText}, warnings: nil}
 	}
-	step := &step{lineNo: stepToken.lineNo, value: stepValue, lineText: strings.TrimSpace(stepToken.lineText)}
+	step := &step{lineNo: stepToken.lineNo, value: stepValue, lineText: strings.TrimSpace(stepToken.lineText), span: stepToken.span}
 	arguments := make([]*stepArg, 0)
 	var warnings []*warning
 	for i, argType := range argsType {
@@ -495,6 +683,9 @@ func (spec *specification) createStepUsingLookup(stepToken *token, lookup *argLo
 		if parseDetails != nil && parseDetails.error != nil {
 			return nil, parseDetails
 		}
+		if i < len(stepToken.argSpans) {
+			argument.span = stepToken.argSpans[i]
+		}
 		arguments = append(arguments, argument)
 		if parseDetails != nil && parseDetails.warnings != nil {
 			for _, warn := range parseDetails.warnings {
@@ -510,6 +701,9 @@ func (specification *specification) processConceptStepsFrom(conceptDictionary *c
 	for _, step := range specification.contexts {
 		specification.processConceptStep(step, conceptDictionary)
 	}
+	for _, step := range specification.tearDownSteps {
+		specification.processConceptStep(step, conceptDictionary)
+	}
 	for _, scenario := range specification.scenarios {
 		for _, step := range scenario.steps {
 			specification.processConceptStep(step, conceptDictionary)
@@ -561,14 +755,24 @@ func (specification *specification) addContext(contextStep *step) {
 	specification.addItem(contextStep)
 }
 
+func (specification *specification) addTearDown(tearDown *tearDown) {
+	specification.addItem(tearDown)
+}
+
+func (specification *specification) addTearDownStep(tearDownStep *step) {
+	specification.tearDownSteps = append(specification.tearDownSteps, tearDownStep)
+	specification.addItem(tearDownStep)
+}
+
 func (specification *specification) addComment(comment *comment) {
 	specification.comments = append(specification.comments, comment)
 	specification.addItem(comment)
 }
 
+//the item is the data table itself, as the rows added later to a vertical table are not shared with a copy of it
 func (specification *specification) addDataTable(table *table) {
 	specification.dataTable.table = *table
-	specification.addItem(table)
+	specification.addItem(&specification.dataTable.table)
 }
 
 func (specification *specification) addExternalDataTable(externalTable *dataTable) {
@@ -581,6 +785,16 @@ func (specification *specification) addTags(tags *tags) {
 	specification.addItem(tags)
 }
 
+func (specification *specification) addMetadata(metadata *metadata) {
+	specification.metadata = metadata
+	specification.addItem(metadata)
+}
+
+func (specification *specification) addSkip(skip *skip) {
+	specification.skip = skip
+	specification.addItem(skip)
+}
+
 func (specification *specification) latestScenario() *scenario {
 	return specification.scenarios[len(specification.scenarios)-1]
 }
@@ -589,6 +803,10 @@ func (specification *specification) latestContext() *step {
 	return specification.contexts[len(specification.contexts)-1]
 }
 
+func (specification *specification) latestTearDownStep() *step {
+	return specification.tearDownSteps[len(specification.tearDownSteps)-1]
+}
+
 func (specParser *specParser) validateSpec(specification *specification) *parseError {
 	if len(specification.items) == 0 {
 		return &parseError{lineNo: 1, message: "Spec does not have any elements"}
@@ -600,6 +818,12 @@ func (specParser *specParser) validateSpec(specification *specification) *parseE
 	if dataTable.isInitialized() && dataTable.getRowCount() == 0 {
 		return &parseError{lineNo: dataTable.lineNo, message: "Data table should have at least 1 data row"}
 	}
+	for _, scenario := range specification.scenarios {
+		scenarioDataTable := scenario.dataTable.table
+		if scenarioDataTable.isInitialized() && scenarioDataTable.getRowCount() == 0 {
+			return &parseError{lineNo: scenarioDataTable.lineNo, message: "Data table should have at least 1 data row"}
+		}
+	}
 	return nil
 }
 
@@ -672,6 +896,22 @@ func (step *step) populateFragments() {
 
 }
 
+// Copies the specification so that it can be filtered and executed without affecting the original. The scenarios
+// and steps are copied as they are changed during execution, while the rest is shared with the original.
+func (spec *specification) getCopy() *specification {
+	specCopy := *spec
+	copies := newItemCopies()
+	specCopy.scenarios = make([]*scenario, len(spec.scenarios))
+	for i, scenario := range spec.scenarios {
+		specCopy.scenarios[i] = scenario.getCopy()
+		copies.scenarios[scenario] = specCopy.scenarios[i]
+	}
+	specCopy.contexts = copies.copySteps(spec.contexts)
+	specCopy.tearDownSteps = copies.copySteps(spec.tearDownSteps)
+	specCopy.items = copies.copyItems(spec.items)
+	return &specCopy
+}
+
 func (spec *specification) filter(filter specItemFilter) {
 	for i := 0; i < len(spec.items); i++ {
 		if filter.filter(spec.items[i]) {
@@ -718,6 +958,10 @@ func (spec *specification) renameSteps(oldStep step, newStep step, orderMap map[
 		isConcept := false
 		isRefactored = step.rename(oldStep, newStep, isRefactored, orderMap, &isConcept)
 	}
+	for _, step := range spec.tearDownSteps {
+		isConcept := false
+		isRefactored = step.rename(oldStep, newStep, isRefactored, orderMap, &isConcept)
+	}
 	for _, scenario := range spec.scenarios {
 		refactor := scenario.renameSteps(oldStep, newStep, orderMap)
 		if refactor {
@@ -729,11 +973,13 @@ func (spec *specification) renameSteps(oldStep step, newStep step, orderMap map[
 
 func (spec *specification) createStepArg(argValue string, typeOfArg string, token *token, lookup *argLookup) (*stepArg, *parseDetailResult) {
 	if typeOfArg == "special" {
-		resolvedArgValue, err := newSpecialTypeResolver().resolve(argValue)
+		resolvedArgValue, err := newSpecialTypeResolverFor(spec.fileName).resolve(argValue)
 		if err != nil {
 			switch err.(type) {
 			case invalidSpecialParamError:
 				return treatArgAsDynamic(argValue, token, lookup)
+			case specialParamOutsideProjectError:
+				return nil, &parseDetailResult{error: &parseError{lineNo: token.lineNo, message: fmt.Sprintf("Dynamic parameter <%s> could not be resolved. %s", argValue, err.Error()), lineText: token.lineText}}
 			default:
 				return nil, &parseDetailResult{error: &parseError{lineNo: token.lineNo, message: fmt.Sprintf("Dynamic parameter <%s> could not be resolved", argValue), lineText: token.lineText}}
 			}
@@ -773,31 +1019,65 @@ func validateDynamicArg(argValue string, token *token, lookup *argLookup) (*step
 
 //Step value is modified when inline table is found to account for the new parameter by appending {}
 //todo validate headers for dynamic
-func addInlineTableHeader(step *step, token *token) {
+//The header row of a vertical table is its first row, so its values are resolved like the values of any other row.
+func addInlineTableHeader(step *step, token *token, argLookup *argLookup) parseResult {
 	step.value = fmt.Sprintf("%s %s", step.value, PARAMETER_PLACEHOLDER)
 	step.hasInlineTable = true
+	if token.isVertical {
+		step.addArgs(&stepArg{argType: tableArg, table: table{isVertical: true, orientation: token.orientation}, span: token.span})
+		return addInlineTableRow(step, token, argLookup)
+	}
 	step.addInlineTableHeaders(token.args)
+	tableArg := step.args[len(step.args)-1]
+	tableArg.table.headerSpans = token.argSpans
+	tableArg.span = token.span
+	step.populateFragments()
+	return parseResult{ok: true}
+}
 
+//Step value is modified when a text block is found to account for the new parameter by appending {}.
+//The opening fence is kept as the name of the argument, so that the block can be formatted back as it was.
+func addTextBlock(step *step, token *token) {
+	step.value = fmt.Sprintf("%s %s", step.value, PARAMETER_PLACEHOLDER)
+	step.addArgs(&stepArg{argType: specialString, value: token.value, name: token.lineText, span: token.span})
+}
+
+//the header row of a vertical data table is its first row
+func newTableWithHeaderRow(token *token) *table {
+	dataTable := &table{lineNo: token.lineNo, isVertical: token.isVertical, orientation: token.orientation}
+	if token.isVertical {
+		dataTable.addRowValuesWithSpans(token.args, token.argSpans)
+	} else {
+		dataTable.addHeaders(token.args)
+		dataTable.headerSpans = token.argSpans
+	}
+	return dataTable
 }
 
 func addInlineTableRow(step *step, token *token, argLookup *argLookup) parseResult {
 	dynamicArgMatcher := regexp.MustCompile("^<(.*)>$")
 	tableValues := make([]tableCell, 0)
 	warnings := make([]*warning, 0)
-	for _, tableValue := range token.args {
+	for i, tableValue := range token.args {
+		var cellSpan span
+		if i < len(token.argSpans) {
+			cellSpan = token.argSpans[i]
+		}
 		if dynamicArgMatcher.MatchString(tableValue) {
 			match := dynamicArgMatcher.FindAllStringSubmatch(tableValue, -1)
 			param := match[0][1]
 			if !argLookup.containsArg(param) {
-				tableValues = append(tableValues, tableCell{value: tableValue, cellType: static})
+				tableValues = append(tableValues, tableCell{value: tableValue, cellType: static, span: cellSpan})
 				warnings = append(warnings, &warning{lineNo: token.lineNo, message: fmt.Sprintf("Dynamic param <%s> could not be resolved, Treating it as static param", param)})
 			} else {
-				tableValues = append(tableValues, tableCell{value: param, cellType: dynamic})
+				tableValues = append(tableValues, tableCell{value: param, cellType: dynamic, span: cellSpan})
 			}
 		} else {
-			tableValues = append(tableValues, tableCell{value: tableValue, cellType: static})
+			tableValues = append(tableValues, tableCell{value: tableValue, cellType: static, span: cellSpan})
 		}
 	}
+	tableArg := step.args[len(step.args)-1]
+	tableArg.span.endLine, tableArg.span.endColumn = token.span.endLine, token.span.endColumn
 	step.addInlineTableRow(tableValues)
 	return parseResult{ok: true, warnings: warnings}
 }
@@ -851,26 +1131,35 @@ func (lookup *argLookup) getCopy() *argLookup {
 
 func (lookup *argLookup) fromDataTableRow(datatable *table, index int) *argLookup {
 	dataTableLookup := new(argLookup)
-	if !datatable.isInitialized() {
-		return dataTableLookup
-	}
-	for _, header := range datatable.headers {
-		dataTableLookup.addArgName(header)
-		dataTableLookup.addArgValue(header, &stepArg{value: datatable.get(header)[index].value, argType: static})
-	}
+	dataTableLookup.addDataTableRow(datatable, index)
 	return dataTableLookup
 }
 
 //create an empty lookup with only args to resolve dynamic params for steps
 func (lookup *argLookup) fromDataTable(datatable *table) *argLookup {
 	dataTableLookup := new(argLookup)
+	dataTableLookup.addDataTableHeaders(datatable)
+	return dataTableLookup
+}
+
+//adds the values of a data table row, overriding the args already present with the same name
+func (lookup *argLookup) addDataTableRow(datatable *table, index int) {
 	if !datatable.isInitialized() {
-		return dataTableLookup
+		return
 	}
 	for _, header := range datatable.headers {
-		dataTableLookup.addArgName(header)
+		lookup.addArgName(header)
+		lookup.addArgValue(header, &stepArg{value: datatable.get(header)[index].value, argType: static})
+	}
+}
+
+func (lookup *argLookup) addDataTableHeaders(datatable *table) {
+	if !datatable.isInitialized() {
+		return
+	}
+	for _, header := range datatable.headers {
+		lookup.addArgName(header)
 	}
-	return dataTableLookup
 }
 
 func (warning *warning) String() string {
@@ -896,11 +1185,49 @@ func (scenario *scenario) addTags(tags *tags) {
 	scenario.addItem(tags)
 }
 
+func (scenario *scenario) addSkip(skip *skip) {
+	scenario.skip = skip
+	scenario.addItem(skip)
+}
+
 func (scenario *scenario) addComment(comment *comment) {
 	scenario.comments = append(scenario.comments, comment)
 	scenario.addItem(comment)
 }
 
+func (scenario *scenario) addDataTable(table *table) {
+	scenario.dataTable.table = *table
+	scenario.addItem(&scenario.dataTable.table)
+}
+
+func (scenario *scenario) addExternalDataTable(externalTable *dataTable) {
+	scenario.dataTable = *externalTable
+	scenario.addItem(externalTable)
+}
+
+// A scenario can have its own data table only directly under its heading, before any of its steps
+func (scenario *scenario) canAddDataTable() bool {
+	return len(scenario.steps) == 0 && !scenario.dataTable.isInitialized()
+}
+
+func (scenario *scenario) isTableDriven() bool {
+	return scenario.dataTable.table.getRowCount() > 0
+}
+
+var headingPlaceholderRegex = regexp.MustCompile("<([^<>]+)>")
+
+// Replaces the <column> placeholders in the heading with the values from the data table row being executed.
+// Placeholders which are not columns of the data tables are left as they are.
+func (scenario *scenario) resolveHeading(dataTableLookup *argLookup) string {
+	return headingPlaceholderRegex.ReplaceAllStringFunc(scenario.heading.value, func(placeholder string) string {
+		param := headingPlaceholderRegex.FindStringSubmatch(placeholder)[1]
+		if !dataTableLookup.containsArg(param) || dataTableLookup.getArg(param) == nil {
+			return placeholder
+		}
+		return dataTableLookup.getArg(param).value
+	})
+}
+
 func (scenario *scenario) renameSteps(oldStep step, newStep step, orderMap map[int]int) bool {
 	isRefactored := false
 	for _, step := range scenario.steps {
@@ -933,6 +1260,18 @@ func (tags *tags) kind() tokenKind {
 	return tagKind
 }
 
+func (metadata *metadata) kind() tokenKind {
+	return metadataKind
+}
+
+func (skip *skip) kind() tokenKind {
+	return skipKind
+}
+
+func (tearDown *tearDown) kind() tokenKind {
+	return tearDownKind
+}
+
 func (step step) kind() tokenKind {
 	return stepKind
 }
@@ -940,7 +1279,7 @@ func (step step) kind() tokenKind {
 func (specification *specification) getSpecItems() []item {
 	specItems := make([]item, 0)
 	for _, item := range specification.items {
-		if item.kind() != scenarioKind {
+		if item.kind() != scenarioKind && item.kind() != tearDownKind && item.kind() != skipKind && item.kind() != metadataKind {
 			specItems = append(specItems, item)
 		}
 	}
@@ -965,6 +1304,81 @@ func (self *step) getCopy() *step {
 	return copiedConceptStep
 }
 
+// Copies the step along with its args, fragments and concept steps, so that the copy can be executed alongside the original
+func (self *step) deepCopy() *step {
+	stepCopy := new(step)
+	*stepCopy = *self
+	if self.args != nil {
+		stepCopy.args = self.deepCopyStepArgs()
+	}
+	if self.fragments != nil {
+		stepCopy.fragments = makeFragmentsCopy(self.fragments)
+	}
+	stepCopy.lookup = *self.lookup.getCopy()
+	if self.conceptSteps != nil {
+		stepCopy.conceptSteps = make([]*step, len(self.conceptSteps))
+		for i, conceptStep := range self.conceptSteps {
+			stepCopy.conceptSteps[i] = conceptStep.deepCopy()
+			if conceptStep.parent == self {
+				stepCopy.conceptSteps[i].parent = stepCopy
+			}
+		}
+	}
+	return stepCopy
+}
+
+func (scenario *scenario) getCopy() *scenario {
+	scenarioCopy := *scenario
+	copies := newItemCopies()
+	scenarioCopy.steps = copies.copySteps(scenario.steps)
+	scenarioCopy.items = copies.copyItems(scenario.items)
+	return &scenarioCopy
+}
+
+// itemCopies records the copies of the steps and scenarios, so that the items referring to them can be replaced by their copies.
+type itemCopies struct {
+	steps     map[*step]*step
+	scenarios map[*scenario]*scenario
+}
+
+func newItemCopies() *itemCopies {
+	return &itemCopies{steps: make(map[*step]*step), scenarios: make(map[*scenario]*scenario)}
+}
+
+func (copies *itemCopies) copySteps(steps []*step) []*step {
+	if steps == nil {
+		return nil
+	}
+	stepsCopy := make([]*step, len(steps))
+	for i, step := range steps {
+		stepsCopy[i] = step.deepCopy()
+		copies.steps[step] = stepsCopy[i]
+	}
+	return stepsCopy
+}
+
+// Replaces the items with their copies, keeping the items which were not copied
+func (copies *itemCopies) copyItems(items []item) []item {
+	if items == nil {
+		return nil
+	}
+	itemsCopy := make([]item, len(items))
+	for i, item := range items {
+		itemsCopy[i] = item
+		switch original := item.(type) {
+		case *step:
+			if stepCopy, ok := copies.steps[original]; ok {
+				itemsCopy[i] = stepCopy
+			}
+		case *scenario:
+			if scenarioCopy, ok := copies.scenarios[original]; ok {
+				itemsCopy[i] = scenarioCopy
+			}
+		}
+	}
+	return itemsCopy
+}
+
 func (self *step) copyFrom(another *step) {
 	self.isConcept = another.isConcept
 
@@ -990,6 +1404,7 @@ func (self *step) copyFrom(another *step) {
 	}
 
 	self.lineNo = another.lineNo
+	self.span = another.span
 	self.lineText = another.lineText
 	self.hasInlineTable = another.hasInlineTable
 	self.value = another.value
@@ -1018,6 +1433,28 @@ func convertToStepText(fragments []*gauge_messages.Fragment) string {
 	return stepText
 }
 
+// Records the errors ordered by their line numbers. The error of the result is the first of them.
+func (result *parseResult) addErrors(parseErrors ...*parseError) {
+	if len(parseErrors) == 0 {
+		return
+	}
+	result.errors = append(result.errors, parseErrors...)
+	sort.Stable(byLineNo(result.errors))
+	result.error = result.errors[0]
+	result.ok = false
+}
+
+func (result *parseResult) getErrors() []*parseError {
+	if len(result.errors) == 0 && result.error != nil {
+		return []*parseError{result.error}
+	}
+	return result.errors
+}
+
 func (result *parseResult) Error() string {
-	return fmt.Sprintf("[ParseError] %s : %s", result.fileName, result.error.Error())
+	errorMessages := make([]string, 0)
+	for _, err := range result.getErrors() {
+		errorMessages = append(errorMessages, fmt.Sprintf("[ParseError] %s : %s", result.fileName, err.Error()))
+	}
+	return strings.Join(errorMessages, "\n")
 }
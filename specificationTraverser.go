@@ -18,14 +18,19 @@
 package main
 
 type specTraverser interface {
+	specMetadata(*metadata)
 	specHeading(*heading)
 	specTags(*tags)
+	specSkip(*skip)
 	dataTable(*table)
 	externalDataTable(*dataTable)
 	contextStep(*step)
+	tearDown(*tearDown)
+	tearDownStep(*step)
 	scenario(*scenario)
 	scenarioHeading(*heading)
 	scenarioTags(*tags)
+	scenarioSkip(*skip)
 	step(*step)
 	comment(*comment)
 }
@@ -33,25 +38,42 @@ type specTraverser interface {
 type scenarioTraverser interface {
 	scenarioHeading(*heading)
 	scenarioTags(*tags)
+	scenarioSkip(*skip)
+	dataTable(*table)
+	externalDataTable(*dataTable)
 	step(*step)
 	comment(*comment)
 }
 
+//metadata is at the top of the spec, even above its heading
 func (spec *specification) traverse(traverser specTraverser) {
+	if spec.metadata != nil {
+		traverser.specMetadata(spec.metadata)
+	}
 	traverser.specHeading(spec.heading)
+	isTearDown := false
 	for _, item := range spec.items {
 		switch item.kind() {
 		case scenarioKind:
 			item.(*scenario).traverse(traverser)
 			traverser.scenario(item.(*scenario))
+		case tearDownKind:
+			isTearDown = true
+			traverser.tearDown(item.(*tearDown))
 		case stepKind:
-			traverser.contextStep(item.(*step))
+			if isTearDown {
+				traverser.tearDownStep(item.(*step))
+			} else {
+				traverser.contextStep(item.(*step))
+			}
 		case commentKind:
 			traverser.comment(item.(*comment))
 		case tableKind:
 			traverser.dataTable(item.(*table))
 		case tagKind:
 			traverser.specTags(item.(*tags))
+		case skipKind:
+			traverser.specSkip(item.(*skip))
 		case dataTableKind:
 			if !item.(*dataTable).isExternal {
 				traverser.dataTable(&item.(*dataTable).table)
@@ -72,6 +94,12 @@ func (scenario *scenario) traverse(traverser scenarioTraverser) {
 			traverser.comment(item.(*comment))
 		case tagKind:
 			traverser.scenarioTags(item.(*tags))
+		case skipKind:
+			traverser.scenarioSkip(item.(*skip))
+		case tableKind:
+			traverser.dataTable(item.(*table))
+		case dataTableKind:
+			traverser.externalDataTable(item.(*dataTable))
 		}
 	}
 }
@@ -297,6 +297,54 @@ func (s *MySuite) TestStepWithInlineTableWithDynamicParam(c *C) {
 	c.Assert(inlineTable.get("name")[2].cellType, Equals, static)
 }
 
+func (s *MySuite) TestStepWithVerticalInlineTable(c *C) {
+	tokens := []*token{
+		&token{kind: specKind, value: "Spec Heading", lineNo: 1},
+		&token{kind: tableHeader, args: []string{"type1"}},
+		&token{kind: tableRow, args: []string{"1"}},
+		&token{kind: scenarioKind, value: "Scenario Heading", lineNo: 2},
+		&token{kind: stepKind, value: "Step with inline table", lineNo: 3},
+		&token{kind: tableHeader, args: []string{"name", "<type1>"}, isVertical: true},
+		&token{kind: tableRow, args: []string{"---", "---"}, isVertical: true},
+		&token{kind: tableRow, args: []string{"email", "foo@b.c"}, isVertical: true},
+	}
+
+	spec, result := new(specParser).createSpecification(tokens, new(conceptDictionary))
+
+	c.Assert(result.ok, Equals, true)
+	step := spec.scenarios[0].steps[0]
+	c.Assert(step.value, Equals, "Step with inline table {}")
+	c.Assert(step.args[0].argType, Equals, tableArg)
+	inlineTable := step.args[0].table
+	c.Assert(inlineTable.isVertical, Equals, true)
+	c.Assert(inlineTable.headers, DeepEquals, []string{"name", "email"})
+	c.Assert(inlineTable.getRowCount(), Equals, 1)
+	c.Assert(inlineTable.get("name")[0].value, Equals, "type1")
+	c.Assert(inlineTable.get("name")[0].cellType, Equals, dynamic)
+	c.Assert(inlineTable.get("email")[0].value, Equals, "foo@b.c")
+
+	protoTable := convertToProtoTableParam(&inlineTable)
+	c.Assert(protoTable.GetHeaders().GetCells(), DeepEquals, []string{"name", "email"})
+	c.Assert(len(protoTable.GetRows()), Equals, 1)
+}
+
+func (s *MySuite) TestSpecWithVerticalDataTable(c *C) {
+	tokens := []*token{
+		&token{kind: specKind, value: "Spec Heading", lineNo: 1},
+		&token{kind: tableHeader, args: []string{"id", "1", "2"}, isVertical: true},
+		&token{kind: tableRow, args: []string{"name", "foo", "bar"}, isVertical: true},
+		&token{kind: scenarioKind, value: "Scenario Heading", lineNo: 2},
+		&token{kind: stepKind, value: "Step with {dynamic}", lineNo: 3, args: []string{"name"}},
+	}
+
+	spec, result := new(specParser).createSpecification(tokens, new(conceptDictionary))
+
+	c.Assert(result.ok, Equals, true)
+	c.Assert(spec.dataTable.table.headers, DeepEquals, []string{"id", "name"})
+	c.Assert(spec.dataTable.table.getRows(), DeepEquals, [][]string{[]string{"1", "foo"}, []string{"2", "bar"}})
+	c.Assert(spec.scenarios[0].steps[0].args[0].argType, Equals, dynamic)
+}
+
 func (s *MySuite) TestStepWithInlineTableWithUnResolvableDynamicParam(c *C) {
 	tokens := []*token{
 		&token{kind: specKind, value: "Spec Heading", lineNo: 1},
@@ -408,9 +456,8 @@ func (s *MySuite) TestWarningWhenParsingTableOccursWithoutStep(c *C) {
 	_, result := new(specParser).createSpecification(tokens, new(conceptDictionary))
 
 	c.Assert(result.ok, Equals, true)
-	c.Assert(len(result.warnings), Equals, 2)
-	c.Assert(result.warnings[0].String(), Equals, "line no: 3, Table not associated with a step, ignoring table")
-	c.Assert(result.warnings[1].String(), Equals, "line no: 8, Table not associated with a step, ignoring table")
+	c.Assert(len(result.warnings), Equals, 1)
+	c.Assert(result.warnings[0].String(), Equals, "line no: 8, Table not associated with a step, ignoring table")
 
 }
 
@@ -467,6 +514,26 @@ func (s *MySuite) TestErrorOnAddingDynamicParamterWithoutADataTable(c *C) {
 
 }
 
+func (s *MySuite) TestCollectingAllErrorsInSpec(c *C) {
+	tokens := []*token{
+		&token{kind: specKind, value: "Spec Heading", lineNo: 1},
+		&token{kind: scenarioKind, value: "Scenario Heading", lineNo: 2},
+		&token{kind: stepKind, value: "Step with a {dynamic}", args: []string{"foo"}, lineNo: 3, lineText: "*Step with a <foo>"},
+		&token{kind: stepKind, value: "Valid step", lineNo: 4, lineText: "*Valid step"},
+		&token{kind: stepKind, value: "Step with a {dynamic}", args: []string{"bar"}, lineNo: 5, lineText: "*Step with a <bar>"},
+	}
+
+	_, result := new(specParser).createSpecification(tokens, new(conceptDictionary))
+
+	c.Assert(result.ok, Equals, false)
+	c.Assert(len(result.errors), Equals, 2)
+	c.Assert(result.error, Equals, result.errors[0])
+	c.Assert(result.errors[0].message, Equals, "Dynamic parameter <foo> could not be resolved")
+	c.Assert(result.errors[0].lineNo, Equals, 3)
+	c.Assert(result.errors[1].message, Equals, "Dynamic parameter <bar> could not be resolved")
+	c.Assert(result.errors[1].lineNo, Equals, 5)
+}
+
 func (s *MySuite) TestErrorOnAddingDynamicParamterWithoutDataTableHeaderValue(c *C) {
 	tokens := []*token{
 		&token{kind: specKind, value: "Spec Heading", lineNo: 1},
@@ -975,3 +1042,261 @@ func (s *MySuite) TestCreateInValidSpecialArgInStep(c *C) {
 	c.Assert(len(parseResults.warnings), Equals, 1)
 	c.Assert(parseResults.warnings[0].message, Equals, "Could not resolve special param type <unknown:foo>. Treating it as dynamic param.")
 }
+
+func (s *MySuite) TestTearDownSteps(c *C) {
+	tokens := []*token{
+		&token{kind: specKind, value: "Spec Heading", lineNo: 1},
+		&token{kind: stepKind, value: "Context step", lineNo: 2},
+		&token{kind: scenarioKind, value: "Scenario Heading", lineNo: 3},
+		&token{kind: stepKind, value: "Example step", lineNo: 4},
+		&token{kind: tearDownKind, value: "___", lineNo: 5},
+		&token{kind: stepKind, value: "Clean up", lineNo: 6},
+		&token{kind: commentKind, value: "A comment", lineNo: 7},
+		&token{kind: stepKind, value: "Close browser", lineNo: 8},
+	}
+
+	spec, result := new(specParser).createSpecification(tokens, new(conceptDictionary))
+	c.Assert(result.ok, Equals, true)
+	c.Assert(len(spec.contexts), Equals, 1)
+	c.Assert(len(spec.scenarios[0].steps), Equals, 1)
+	c.Assert(len(spec.scenarios[0].comments), Equals, 0)
+	c.Assert(len(spec.tearDownSteps), Equals, 2)
+	c.Assert(spec.tearDownSteps[0].value, Equals, "Clean up")
+	c.Assert(spec.tearDownSteps[1].value, Equals, "Close browser")
+	c.Assert(spec.comments[0].value, Equals, "A comment")
+	c.Assert(spec.items[2].kind(), Equals, tearDownKind)
+	c.Assert(spec.items[3], Equals, spec.tearDownSteps[0])
+}
+
+func (s *MySuite) TestTearDownStepWithInlineTable(c *C) {
+	tokens := []*token{
+		&token{kind: specKind, value: "Spec Heading", lineNo: 1},
+		&token{kind: scenarioKind, value: "Scenario Heading", lineNo: 2},
+		&token{kind: stepKind, value: "Example step", lineNo: 3},
+		&token{kind: tearDownKind, value: "___", lineNo: 4},
+		&token{kind: stepKind, value: "Delete users", lineNo: 5},
+		&token{kind: tableHeader, args: []string{"id", "name"}},
+		&token{kind: tableRow, args: []string{"1", "foo"}},
+	}
+
+	spec, result := new(specParser).createSpecification(tokens, new(conceptDictionary))
+	c.Assert(result.ok, Equals, true)
+	c.Assert(len(spec.scenarios[0].steps[0].args), Equals, 0)
+	tearDownStep := spec.tearDownSteps[0]
+	c.Assert(tearDownStep.value, Equals, "Delete users {}")
+	c.Assert(tearDownStep.args[0].argType, Equals, tableArg)
+	c.Assert(tearDownStep.args[0].table.get("name")[0].value, Equals, "foo")
+}
+
+func (s *MySuite) TestTableAfterTearDownSeparatorWithoutStepIsAComment(c *C) {
+	tokens := []*token{
+		&token{kind: specKind, value: "Spec Heading", lineNo: 1},
+		&token{kind: scenarioKind, value: "Scenario Heading", lineNo: 2},
+		&token{kind: tearDownKind, value: "___", lineNo: 3},
+		&token{kind: tableHeader, args: []string{"id", "name"}, lineText: "|id|name|", lineNo: 4},
+	}
+
+	spec, result := new(specParser).createSpecification(tokens, new(conceptDictionary))
+	c.Assert(result.ok, Equals, true)
+	c.Assert(spec.dataTable.table.isInitialized(), Equals, false)
+	c.Assert(result.warnings[0].message, Equals, "Table not associated with a step, ignoring table")
+}
+
+func (s *MySuite) TestErrorWhenScenarioIsDefinedAfterTearDownSteps(c *C) {
+	tokens := []*token{
+		&token{kind: specKind, value: "Spec Heading", lineNo: 1},
+		&token{kind: scenarioKind, value: "First scenario", lineNo: 2},
+		&token{kind: tearDownKind, value: "___", lineNo: 3},
+		&token{kind: stepKind, value: "Clean up", lineNo: 4},
+		&token{kind: scenarioKind, value: "Second scenario", lineNo: 5},
+	}
+
+	_, result := new(specParser).createSpecification(tokens, new(conceptDictionary))
+	c.Assert(result.ok, Equals, false)
+	c.Assert(result.error.message, Equals, "Parse error: Scenario should be defined before the teardown steps")
+	c.Assert(result.error.lineNo, Equals, 5)
+}
+
+func (s *MySuite) TestScenarioDataTable(c *C) {
+	tokens := []*token{
+		&token{kind: specKind, value: "Spec Heading", lineNo: 1},
+		&token{kind: scenarioKind, value: "First scenario", lineNo: 2},
+		&token{kind: tableHeader, args: []string{"id", "name"}, lineNo: 3},
+		&token{kind: tableRow, args: []string{"1", "foo"}, lineNo: 4},
+		&token{kind: tableRow, args: []string{"2", "bar"}, lineNo: 5},
+		&token{kind: stepKind, value: "Step with {dynamic}", args: []string{"name"}, lineNo: 6},
+		&token{kind: scenarioKind, value: "Second scenario", lineNo: 7},
+		&token{kind: stepKind, value: "Example step", lineNo: 8},
+	}
+
+	spec, result := new(specParser).createSpecification(tokens, new(conceptDictionary))
+	c.Assert(result.ok, Equals, true)
+	c.Assert(len(result.warnings), Equals, 0)
+	c.Assert(spec.dataTable.isInitialized(), Equals, false)
+
+	firstScenario := spec.scenarios[0]
+	c.Assert(firstScenario.isTableDriven(), Equals, true)
+	c.Assert(firstScenario.dataTable.table.getRowCount(), Equals, 2)
+	c.Assert(firstScenario.dataTable.table.get("name")[1].value, Equals, "bar")
+	c.Assert(firstScenario.items[0], DeepEquals, &firstScenario.dataTable.table)
+	c.Assert(firstScenario.steps[0].args[0].argType, Equals, dynamic)
+
+	c.Assert(spec.scenarios[1].isTableDriven(), Equals, false)
+}
+
+func (s *MySuite) TestErrorWhenStepRefersToDataTableOfAnotherScenario(c *C) {
+	tokens := []*token{
+		&token{kind: specKind, value: "Spec Heading", lineNo: 1},
+		&token{kind: scenarioKind, value: "First scenario", lineNo: 2},
+		&token{kind: tableHeader, args: []string{"name"}, lineNo: 3},
+		&token{kind: tableRow, args: []string{"foo"}, lineNo: 4},
+		&token{kind: stepKind, value: "Example step", lineNo: 5},
+		&token{kind: scenarioKind, value: "Second scenario", lineNo: 6},
+		&token{kind: stepKind, value: "Step with {dynamic}", args: []string{"name"}, lineNo: 7},
+	}
+
+	_, result := new(specParser).createSpecification(tokens, new(conceptDictionary))
+	c.Assert(result.ok, Equals, false)
+	c.Assert(result.error.message, Equals, "Dynamic parameter <name> could not be resolved")
+	c.Assert(result.error.lineNo, Equals, 7)
+}
+
+func (s *MySuite) TestWarningWhenScenarioDataTableIsUsedWithSpecDataTable(c *C) {
+	tokens := []*token{
+		&token{kind: specKind, value: "Spec Heading", lineNo: 1},
+		&token{kind: tableHeader, args: []string{"id"}, lineNo: 2},
+		&token{kind: tableRow, args: []string{"1"}, lineNo: 3},
+		&token{kind: scenarioKind, value: "Scenario Heading", lineNo: 4},
+		&token{kind: tableHeader, args: []string{"name"}, lineNo: 5},
+		&token{kind: tableRow, args: []string{"foo"}, lineNo: 6},
+	}
+
+	spec, result := new(specParser).createSpecification(tokens, new(conceptDictionary))
+	c.Assert(result.ok, Equals, true)
+	c.Assert(spec.scenarios[0].isTableDriven(), Equals, false)
+	c.Assert(result.warnings[0].String(), Equals, "line no: 5, Multiple data table present, ignoring table")
+}
+
+func (s *MySuite) TestErrorWhenScenarioDataTableHasOnlyHeader(c *C) {
+	tokens := []*token{
+		&token{kind: specKind, value: "Spec Heading", lineNo: 1},
+		&token{kind: scenarioKind, value: "Scenario Heading", lineNo: 2},
+		&token{kind: tableHeader, args: []string{"id"}, lineNo: 3},
+		&token{kind: stepKind, value: "Example step", lineNo: 4},
+	}
+
+	_, result := new(specParser).createSpecification(tokens, new(conceptDictionary))
+	c.Assert(result.ok, Equals, false)
+	c.Assert(result.error.message, Equals, "Data table should have at least 1 data row")
+	c.Assert(result.error.lineNo, Equals, 3)
+}
+
+func (s *MySuite) TestResolveScenarioHeadingWithDataTableParameters(c *C) {
+	scenario := &scenario{}
+	scenario.addHeading(&heading{value: "Login as <user> with <role> <unknown>"})
+	dataTable := &table{}
+	dataTable.addHeaders([]string{"user", "role"})
+	dataTable.addRowValues([]string{"john", "admin"})
+	dataTable.addRowValues([]string{"james", "guest"})
+
+	c.Assert(scenario.resolveHeading(new(argLookup).fromDataTableRow(dataTable, 1)), Equals, "Login as james with guest <unknown>")
+	c.Assert(scenario.resolveHeading(new(argLookup)), Equals, "Login as <user> with <role> <unknown>")
+}
+
+func (s *MySuite) TestTextBlockIsAddedAsSpecialStringArgOfPreviousStep(c *C) {
+	tokens := []*token{
+		&token{kind: specKind, value: "Spec Heading", lineNo: 1},
+		&token{kind: stepKind, value: "Create table", lineNo: 2},
+		&token{kind: textBlockKind, value: "create table foo (id int)", lineText: "```sql", lineNo: 3},
+		&token{kind: scenarioKind, value: "Scenario Heading", lineNo: 6},
+		&token{kind: stepKind, value: "Post payload", lineNo: 7},
+		&token{kind: textBlockKind, value: "{\"id\": 1}", lineText: "```", lineNo: 8},
+	}
+
+	spec, result := new(specParser).createSpecification(tokens, new(conceptDictionary))
+	c.Assert(result.ok, Equals, true)
+
+	context := spec.contexts[0]
+	c.Assert(context.value, Equals, "Create table {}")
+	c.Assert(context.args[0].argType, Equals, specialString)
+	c.Assert(context.args[0].value, Equals, "create table foo (id int)")
+	c.Assert(context.args[0].name, Equals, "```sql")
+
+	step := spec.scenarios[0].steps[0]
+	c.Assert(step.value, Equals, "Post payload {}")
+	c.Assert(step.args[0].argType, Equals, specialString)
+	c.Assert(step.args[0].value, Equals, "{\"id\": 1}")
+	c.Assert(step.fragments[1].GetParameter().GetParameterType(), Equals, gauge_messages.Parameter_Special_String)
+}
+
+func (s *MySuite) TestSkipMarkersOfSpecAndScenarios(c *C) {
+	tokens := []*token{
+		&token{kind: specKind, value: "Spec Heading", lineNo: 1},
+		&token{kind: skipKind, value: "not ready yet", lineNo: 2, lineText: "@skip: not ready yet"},
+		&token{kind: scenarioKind, value: "First scenario", lineNo: 3},
+		&token{kind: stepKind, value: "Example step", lineNo: 4, lineText: "Example step"},
+		&token{kind: skipKind, value: "known issue", lineNo: 5, lineText: "@skip: known issue"},
+		&token{kind: scenarioKind, value: "Second scenario", lineNo: 6},
+		&token{kind: stepKind, value: "Example step", lineNo: 7, lineText: "Example step"},
+	}
+
+	spec, result := new(specParser).createSpecification(tokens, new(conceptDictionary))
+
+	c.Assert(result.ok, Equals, true)
+	c.Assert(spec.skip.reason, Equals, "not ready yet")
+	c.Assert(spec.skip.lineNo, Equals, 2)
+	c.Assert(spec.scenarios[0].skip.reason, Equals, "known issue")
+	c.Assert(spec.scenarios[1].skip, IsNil)
+	c.Assert(len(spec.getSpecItems()), Equals, 0)
+	c.Assert(spec.scenarios[0].items[1].kind(), Equals, skipKind)
+}
+
+func (s *MySuite) TestMultipleSkipMarkersForAScenario(c *C) {
+	tokens := []*token{
+		&token{kind: specKind, value: "Spec Heading", lineNo: 1},
+		&token{kind: scenarioKind, value: "Scenario Heading", lineNo: 2},
+		&token{kind: skipKind, value: "known issue", lineNo: 3, lineText: "@skip: known issue"},
+		&token{kind: skipKind, value: "another issue", lineNo: 4, lineText: "@skip: another issue"},
+		&token{kind: stepKind, value: "Example step", lineNo: 5, lineText: "Example step"},
+	}
+
+	spec, result := new(specParser).createSpecification(tokens, new(conceptDictionary))
+
+	c.Assert(result.ok, Equals, true)
+	c.Assert(len(result.warnings), Equals, 1)
+	c.Assert(result.warnings[0].String(), Equals, "line no: 4, Multiple skip markers present, ignoring marker")
+	c.Assert(spec.scenarios[0].skip.reason, Equals, "known issue")
+	c.Assert(spec.scenarios[0].comments[0].value, Equals, "@skip: another issue")
+}
+
+func (s *MySuite) TestSkipMarkerBeforeSpecHeading(c *C) {
+	tokens := []*token{
+		&token{kind: skipKind, value: "not ready yet", lineNo: 1, lineText: "@skip: not ready yet"},
+		&token{kind: specKind, value: "Spec Heading", lineNo: 2},
+		&token{kind: scenarioKind, value: "Scenario Heading", lineNo: 3},
+		&token{kind: stepKind, value: "Example step", lineNo: 4, lineText: "Example step"},
+	}
+
+	_, result := new(specParser).createSpecification(tokens, new(conceptDictionary))
+
+	c.Assert(result.ok, Equals, false)
+	c.Assert(result.error.message, Equals, "Parse error: Skip marker should be defined after the spec heading")
+	c.Assert(result.error.lineNo, Equals, 1)
+}
+
+func (s *MySuite) TestMetadataOfSpec(c *C) {
+	tokens := []*token{
+		&token{kind: metadataKind, args: []string{"owner", "qa-team", "priority", "high"}, lineNo: 1},
+		&token{kind: specKind, value: "Spec Heading", lineNo: 5},
+		&token{kind: scenarioKind, value: "Scenario Heading", lineNo: 6},
+		&token{kind: stepKind, value: "Example step", lineNo: 7, lineText: "Example step"},
+	}
+
+	spec, result := new(specParser).createSpecification(tokens, new(conceptDictionary))
+
+	c.Assert(result.ok, Equals, true)
+	c.Assert(len(spec.metadata.entries), Equals, 2)
+	c.Assert(*spec.metadata.entries[0], Equals, metadataEntry{key: "owner", value: "qa-team"})
+	c.Assert(*spec.metadata.entries[1], Equals, metadataEntry{key: "priority", value: "high"})
+	c.Assert(len(spec.getSpecItems()), Equals, 0)
+}
@@ -23,6 +23,8 @@ import (
 	"fmt"
 	"github.com/getgauge/common"
 	"strings"
+	"unicode"
+	"unicode/utf8"
 )
 
 type specParser struct {
@@ -32,6 +34,10 @@ type specParser struct {
 	currentState      int
 	processors        map[tokenKind]func(*specParser, *token) (*parseError, bool)
 	conceptDictionary *conceptDictionary
+	markdownBlock     *markdownBlock
+	fileName          string
+	//headers read so far of the vertical table being parsed, nil when not in a vertical table
+	verticalTableHeaders []string
 }
 
 type tokenKind int
@@ -42,6 +48,43 @@ type token struct {
 	lineText string
 	args     []string
 	value    string
+	span     span
+	argSpans []span
+	//column at which the value starts, used to find the columns of the args
+	valueColumn int
+	//set on the rows of a table declared with an orientation marker
+	isVertical bool
+	//orientation of the marker as written, set on the header of a vertical table
+	orientation string
+}
+
+//position of an element in a file. Lines and columns start from 1, and the end is the position of the last character of the element
+type span struct {
+	startLine   int
+	startColumn int
+	endLine     int
+	endColumn   int
+}
+
+//span of the text in a line, leaving out the surrounding whitespace
+func lineSpan(lineNo int, line string) span {
+	trimmedLine := strings.TrimRightFunc(line, unicode.IsSpace)
+	startColumn := utf8.RuneCountInString(trimmedLine) - utf8.RuneCountInString(strings.TrimLeftFunc(trimmedLine, unicode.IsSpace)) + 1
+	return span{startLine: lineNo, startColumn: startColumn, endLine: lineNo, endColumn: utf8.RuneCountInString(trimmedLine)}
+}
+
+//span of the text at the given rune offsets of a token's value
+func (token *token) valueSpan(startOffset int, endOffset int) span {
+	return span{startLine: token.lineNo, startColumn: token.valueColumn + startOffset, endLine: token.lineNo, endColumn: token.valueColumn + endOffset}
+}
+
+//column at which the value starts, where the value is the trailing text of the line
+func valueColumn(line string, value string) int {
+	trimmedLine := strings.TrimRightFunc(line, unicode.IsSpace)
+	if len(value) > len(trimmedLine) {
+		return 1
+	}
+	return utf8.RuneCountInString(trimmedLine[:len(trimmedLine)-len(value)]) + 1
 }
 
 type parseError struct {
@@ -59,6 +102,20 @@ func (se *parseError) Error() string {
 	return fmt.Sprintf("line no: %d, %s", se.lineNo, se.message)
 }
 
+type byLineNo []*parseError
+
+func (s byLineNo) Len() int {
+	return len(s)
+}
+
+func (s byLineNo) Swap(i, j int) {
+	s[i], s[j] = s[j], s[i]
+}
+
+func (s byLineNo) Less(i, j int) bool {
+	return s[i].lineNo < s[j].lineNo
+}
+
 func (token *token) String() string {
 	return fmt.Sprintf("kind:%d, lineNo:%d, value:%s, line:%s, args:%s", token.kind, token.lineNo, token.value, token.lineText, token.args)
 }
@@ -74,6 +131,7 @@ const (
 	contextScope   = 1 << iota
 	conceptScope   = 1 << iota
 	keywordScope   = 1 << iota
+	tearDownScope  = 1 << iota
 )
 
 const (
@@ -87,8 +145,28 @@ const (
 	headingKind
 	tableKind
 	dataTableKind
+	tearDownKind
+	textBlockKind
+	skipKind
+	metadataKind
 )
 
+const textBlockFence = "```"
+
+const skipMarker = "@skip:"
+
+const tearDownSeparator = "___"
+
+const metadataSeparator = "---"
+
+const tableOrientationMarker = "orientation:"
+
+//Orientations of a table written with a record in each column
+var verticalTableOrientations = []string{"vertical", "transposed"}
+
+//Formats of data table files other than csv, written as table: json: <path>
+var dataTableFileFormats = []string{"json", "yaml"}
+
 func (parser *specParser) initialize() {
 	parser.processors = make(map[tokenKind]func(*specParser, *token) (*parseError, bool))
 	parser.processors[specKind] = processSpec
@@ -99,24 +177,42 @@ func (parser *specParser) initialize() {
 	parser.processors[tableHeader] = processTable
 	parser.processors[tableRow] = processTable
 	parser.processors[dataTableKind] = processDataTable
+	parser.processors[tearDownKind] = processTearDown
+	parser.processors[textBlockKind] = processTextBlock
+	parser.processors[skipKind] = processSkip
+	parser.processors[metadataKind] = processMetadata
 }
 
+//lines which cannot be tokenized are skipped, so that the errors in the rest of the spec are reported along with them
 func (parser *specParser) parse(specText string, conceptDictionary *conceptDictionary) (*specification, *parseResult) {
-	tokens, parseError := parser.generateTokens(specText)
-	if parseError != nil {
-		return nil, &parseResult{error: parseError, ok: false}
-	}
-	return parser.createSpecification(tokens, conceptDictionary)
+	tokens, parseErrors := parser.generateTokens(specText)
+	spec, result := parser.convertTokens(tokens, conceptDictionary)
+	result.addErrors(parseErrors...)
+	return parser.validatedSpecification(spec, result)
 }
 
-func (parser *specParser) generateTokens(specText string) ([]*token, *parseError) {
+func (parser *specParser) generateTokens(specText string) ([]*token, []*parseError) {
+	var parseErrors []*parseError
 	parser.initialize()
 	parser.scanner = bufio.NewScanner(strings.NewReader(specText))
 	parser.currentState = initial
+	parser.markdownBlock = nil
+	parser.verticalTableHeaders = nil
 	for line, hasLine := parser.nextLine(); hasLine; line, hasLine = parser.nextLine() {
 		trimmedLine := strings.TrimSpace(line)
 		var newToken *token
-		if len(trimmedLine) == 0 {
+		isMetadataStart := parser.isMetadataStart(trimmedLine)
+		isMarkdownBlockLine := !isMetadataStart && parser.isMarkdownBlockLine(line)
+		if isMetadataStart {
+			metadataToken, err := parser.readMetadata(line)
+			if err != nil {
+				parseErrors = append(parseErrors, err)
+				continue
+			}
+			newToken = metadataToken
+		} else if isMarkdownBlockLine {
+			newToken = &token{kind: commentKind, lineNo: parser.lineNo, lineText: line, value: markdownLineValue(line)}
+		} else if len(trimmedLine) == 0 {
 			newToken = &token{kind: commentKind, lineNo: parser.lineNo, lineText: line, value: "\n"}
 		} else if parser.isScenarioHeading(trimmedLine) {
 			newToken = &token{kind: scenarioKind, lineNo: parser.lineNo, lineText: line, value: strings.TrimSpace(trimmedLine[2:])}
@@ -125,29 +221,50 @@ func (parser *specParser) generateTokens(specText string) ([]*token, *parseError
 		} else if parser.isSpecUnderline(trimmedLine) && (isInState(parser.currentState, commentScope)) {
 			newToken = parser.tokens[len(parser.tokens)-1]
 			newToken.kind = specKind
+			newToken.span.endLine, newToken.span.endColumn = parser.lineNo, lineSpan(parser.lineNo, line).endColumn
 			parser.tokens = append(parser.tokens[:len(parser.tokens)-1])
 		} else if parser.isScenarioUnderline(trimmedLine) && (isInState(parser.currentState, commentScope)) {
 			newToken = parser.tokens[len(parser.tokens)-1]
 			newToken.kind = scenarioKind
+			newToken.span.endLine, newToken.span.endColumn = parser.lineNo, lineSpan(parser.lineNo, line).endColumn
 			parser.tokens = append(parser.tokens[:len(parser.tokens)-1])
+		} else if parser.isTextBlockStart(trimmedLine) {
+			textBlockToken, err := parser.readTextBlock(line)
+			if err != nil {
+				parseErrors = append(parseErrors, err)
+				continue
+			}
+			newToken = textBlockToken
+		} else if parser.isTearDown(trimmedLine) {
+			newToken = &token{kind: tearDownKind, lineNo: parser.lineNo, lineText: line, value: trimmedLine}
 		} else if parser.isStep(trimmedLine) {
 			newToken = &token{kind: stepKind, lineNo: parser.lineNo, lineText: strings.TrimSpace(trimmedLine[1:]), value: strings.TrimSpace(trimmedLine[1:])}
+		} else if found, startIndex := parser.checkSkip(trimmedLine); found {
+			newToken = &token{kind: skipKind, lineNo: parser.lineNo, lineText: line, value: strings.TrimSpace(trimmedLine[startIndex:])}
 		} else if found, startIndex := parser.checkTag(trimmedLine); found {
 			newToken = &token{kind: tagKind, lineNo: parser.lineNo, lineText: line, value: strings.TrimSpace(trimmedLine[startIndex:])}
 		} else if parser.isTableRow(trimmedLine) {
 			kind := parser.tokenKindBasedOnCurrentState(tableScope, tableRow, tableHeader)
 			newToken = &token{kind: kind, lineNo: parser.lineNo, lineText: line, value: strings.TrimSpace(trimmedLine)}
+			parser.setTableOrientation(newToken)
 		} else if value, found := parser.isDataTable(trimmedLine); found {
 			newToken = &token{kind: dataTableKind, lineNo: parser.lineNo, lineText: line, value: value}
 		} else {
 			newToken = &token{kind: commentKind, lineNo: parser.lineNo, lineText: line, value: common.TrimTrailingSpace(line)}
 		}
-		error := parser.accept(newToken)
-		if error != nil {
-			return nil, error
+		if newToken.span.startLine == 0 {
+			newToken.span = lineSpan(parser.lineNo, line)
+			newToken.valueColumn = valueColumn(line, newToken.value)
+		}
+		if err := parser.accept(newToken); err != nil {
+			parseErrors = append(parseErrors, err)
+		}
+		if isMarkdownBlockLine {
+			//a markdown block is not a paragraph, so the line after it is not read as a heading underline or a table row
+			parser.clearState()
 		}
 	}
-	return parser.tokens, nil
+	return parser.tokens, parseErrors
 
 }
 
@@ -179,6 +296,15 @@ func (parser *specParser) checkTag(text string) (bool, int) {
 	return false, -1
 }
 
+// A line starting with "@skip:" marks the spec or scenario above it as skipped, with the rest of the line as the reason.
+// The marker has its own prefix, so that comments starting with the word skip are not taken as markers.
+func (parser *specParser) checkSkip(text string) (bool, int) {
+	if strings.HasPrefix(strings.ToLower(text), skipMarker) {
+		return true, len(skipMarker)
+	}
+	return false, -1
+}
+
 func (parser *specParser) isScenarioHeading(text string) bool {
 	if len(text) > 2 {
 		return text[0] == '#' && text[1] == '#' && text[2] != '#'
@@ -200,27 +326,145 @@ func (parser *specParser) isScenarioUnderline(text string) bool {
 	return isUnderline(text, rune('-'))
 }
 
+// A fenced text block is an argument of the step directly above it. Elsewhere the fence is treated as a comment.
+func (parser *specParser) isTextBlockStart(text string) bool {
+	if !strings.HasPrefix(text, textBlockFence) || len(parser.tokens) == 0 {
+		return false
+	}
+	return parser.tokens[len(parser.tokens)-1].kind == stepKind
+}
+
+// Reads the lines of the text block as they are, till the closing fence. The value of the token is the text
+// within the fences, and the line text is the opening fence along with the info string, if any.
+func (parser *specParser) readTextBlock(openingLine string) (*token, *parseError) {
+	lineNo := parser.lineNo
+	lines := make([]string, 0)
+	for line, hasLine := parser.nextLine(); hasLine; line, hasLine = parser.nextLine() {
+		if strings.TrimSpace(line) == textBlockFence {
+			textBlockSpan := lineSpan(lineNo, openingLine)
+			textBlockSpan.endLine, textBlockSpan.endColumn = parser.lineNo, lineSpan(parser.lineNo, line).endColumn
+			return &token{kind: textBlockKind, lineNo: lineNo, lineText: strings.TrimSpace(openingLine), value: strings.Join(lines, "\n"), span: textBlockSpan}, nil
+		}
+		lines = append(lines, line)
+	}
+	return nil, &parseError{lineNo: lineNo, lineText: openingLine, message: "Text block is not closed"}
+}
+
+// Metadata of a spec is a block of "key: value" lines between two "---" lines, at the very top of the file
+func (parser *specParser) isMetadataStart(text string) bool {
+	return parser.lineNo == 1 && text == metadataSeparator
+}
+
+// Reads the lines till the closing separator. The value of the token is the text between the separators.
+func (parser *specParser) readMetadata(openingLine string) (*token, *parseError) {
+	lineNo := parser.lineNo
+	lines := make([]string, 0)
+	for line, hasLine := parser.nextLine(); hasLine; line, hasLine = parser.nextLine() {
+		if strings.TrimSpace(line) == metadataSeparator {
+			metadataSpan := lineSpan(lineNo, openingLine)
+			metadataSpan.endLine, metadataSpan.endColumn = parser.lineNo, lineSpan(parser.lineNo, line).endColumn
+			return &token{kind: metadataKind, lineNo: lineNo, lineText: openingLine, value: strings.Join(lines, "\n"), span: metadataSpan}, nil
+		}
+		lines = append(lines, line)
+	}
+	return nil, &parseError{lineNo: lineNo, lineText: openingLine, message: "Metadata is not closed"}
+}
+
+// A line of three or more underscores separates the teardown steps from the scenarios
+// Teardown steps follow a "___" line after the scenarios. Other lines of underscores, and those in the spec
+// description, are markdown horizontal rules and are kept as comments.
+func (parser *specParser) isTearDown(text string) bool {
+	return text == tearDownSeparator && parser.hasScenario()
+}
+
+func (parser *specParser) hasScenario() bool {
+	for _, token := range parser.tokens {
+		if token.kind == scenarioKind {
+			return true
+		}
+	}
+	return false
+}
+
 func (parser *specParser) isTableRow(text string) bool {
 	return text[0] == '|' && text[len(text)-1] == '|'
 }
 
+// A table header right after an orientation marker starts a vertical table, the marker is then part of the table
+func (parser *specParser) setTableOrientation(token *token) {
+	if token.kind == tableRow {
+		token.isVertical = parser.verticalTableHeaders != nil
+		return
+	}
+	parser.verticalTableHeaders = nil
+	if len(parser.tokens) == 0 {
+		return
+	}
+	previousToken := parser.tokens[len(parser.tokens)-1]
+	if previousToken.kind == commentKind && previousToken.lineNo == token.lineNo-1 && isVerticalTableMarker(previousToken.value) {
+		parser.tokens = parser.tokens[:len(parser.tokens)-1]
+		parser.verticalTableHeaders = make([]string, 0)
+		token.isVertical = true
+		token.orientation = verticalTableOrientation(previousToken.value)
+	}
+}
+
+func isVerticalTableMarker(text string) bool {
+	return verticalTableOrientation(text) != ""
+}
+
+//the vertical orientation named by the marker, empty if the text is not a vertical table marker
+func verticalTableOrientation(text string) string {
+	lowerCased := strings.ToLower(strings.TrimSpace(text))
+	if !strings.HasPrefix(lowerCased, tableOrientationMarker) {
+		return ""
+	}
+	orientation := strings.TrimSpace(lowerCased[len(tableOrientationMarker):])
+	if !arrayContains(verticalTableOrientations, orientation) {
+		return ""
+	}
+	return orientation
+}
+
 func (parser *specParser) isSpecUnderline(text string) bool {
 	return isUnderline(text, rune('='))
 
 }
 
 func (parser *specParser) isDataTable(text string) (string, bool) {
-	lowerCased := strings.ToLower
-	tableColon := "table:"
-	tableSpaceColon := "table :"
-	if strings.HasPrefix(lowerCased(text), tableColon) {
-		return tableColon + " " + strings.TrimSpace(strings.Replace(lowerCased(text), tableColon, "", 1)), true
-	} else if strings.HasPrefix(lowerCased(text), tableSpaceColon) {
-		return tableColon + " " + strings.TrimSpace(strings.Replace(lowerCased(text), tableSpaceColon, "", 1)), true
+	if source, found := trimSpecialParamPrefix(text, "table"); found {
+		for _, format := range dataTableFileFormats {
+			if filePath, found := trimSpecialParamPrefix(source, format); found {
+				return "table: " + format + ": " + filePath, true
+			}
+		}
+		return "table: " + source, true
 	}
 	return "", false
 }
 
+//returns the text after "<prefix>:" or "<prefix> :", ignoring the case of the prefix
+func trimSpecialParamPrefix(text string, prefix string) (string, bool) {
+	lowerCased := strings.ToLower(text)
+	for _, prefixColon := range []string{prefix + ":", prefix + " :"} {
+		if strings.HasPrefix(lowerCased, prefixColon) {
+			return strings.TrimSpace(text[len(prefixColon):]), true
+		}
+	}
+	return "", false
+}
+
+//special param a data table is resolved from, a json or yaml data table is resolved by the json or yaml special param
+func dataTableParam(dataTableValue string) string {
+	source, _ := trimSpecialParamPrefix(dataTableValue, "table")
+	for _, format := range dataTableFileFormats {
+		if _, found := trimSpecialParamPrefix(source, format); found {
+			return source
+		}
+	}
+	return dataTableValue
+}
+
 func (parser *specParser) accept(token *token) *parseError {
 	error, shouldSkip := parser.processors[token.kind](parser, token)
 	if error != nil {
@@ -241,16 +485,68 @@ func processSpec(parser *specParser, token *token) (*parseError, bool) {
 }
 
 func processDataTable(parser *specParser, token *token) (*parseError, bool) {
-	if len(strings.TrimSpace(strings.Replace(token.value, "table:", "", 1))) == 0 {
+	param := dataTableParam(token.value)
+	if len(strings.TrimSpace(param[strings.Index(param, ":")+1:])) == 0 {
 		return &parseError{lineNo: parser.lineNo, lineText: token.value, message: "Table location not specified"}, true
 	}
-	resolvedArg, err := newSpecialTypeResolver().resolve(token.value)
+	resolvedArg, err := newSpecialTypeResolverFor(parser.fileName).resolve(param)
+	if outsideProjectError, ok := err.(specialParamOutsideProjectError); ok {
+		return &parseError{lineNo: parser.lineNo, lineText: token.value, message: fmt.Sprintf("Could not resolve table from %s. %s", token.lineText, outsideProjectError.message)}, true
+	}
 	if resolvedArg == nil || err != nil {
 		return &parseError{lineNo: parser.lineNo, lineText: token.value, message: fmt.Sprintf("Could not resolve table from %s", token.lineText)}, true
 	}
 	return nil, false
 }
 
+func processTextBlock(parser *specParser, token *token) (*parseError, bool) {
+	parser.clearState()
+	return nil, false
+}
+
+func processTearDown(parser *specParser, token *token) (*parseError, bool) {
+	parser.clearState()
+	return nil, false
+}
+
+func processSkip(parser *specParser, token *token) (*parseError, bool) {
+	parser.clearState()
+	if len(token.value) < 1 {
+		return &parseError{lineNo: parser.lineNo, lineText: token.lineText, message: "Reason for skipping should not be blank"}, true
+	}
+	return nil, false
+}
+
+func processMetadata(parser *specParser, token *token) (*parseError, bool) {
+	parser.clearState()
+	keys := make(map[string]bool)
+	for i, line := range strings.Split(token.value, "\n") {
+		if len(strings.TrimSpace(line)) == 0 {
+			continue
+		}
+		key, value, ok := parseMetadataEntry(line)
+		if !ok {
+			return &parseError{lineNo: token.lineNo + i + 1, lineText: line, message: "Metadata should be of the form key: value"}, true
+		}
+		if keys[key] {
+			return &parseError{lineNo: token.lineNo + i + 1, lineText: line, message: fmt.Sprintf("Duplicate metadata key %s", key)}, true
+		}
+		keys[key] = true
+		//the args of a metadata token are its keys, each followed by its value
+		token.args = append(token.args, key, value)
+	}
+	return nil, false
+}
+
+func parseMetadataEntry(line string) (string, string, bool) {
+	separatorIndex := strings.Index(line, ":")
+	if separatorIndex == -1 {
+		return "", "", false
+	}
+	key := strings.TrimSpace(line[:separatorIndex])
+	return key, strings.TrimSpace(line[separatorIndex+1:]), len(key) > 0
+}
+
 func processScenario(parser *specParser, token *token) (*parseError, bool) {
 	if len(token.value) < 1 {
 		return &parseError{lineNo: parser.lineNo, lineText: token.value, message: "Scenario heading should have at least one character"}, true
@@ -269,10 +565,14 @@ func processTag(parser *specParser, token *token) (*parseError, bool) {
 	parser.clearState()
 	tokens := splitAndTrimTags(token.value)
 
-	for _, tagValue := range tokens {
-		if len(tagValue) > 0 {
+	offset := 0
+	for i, untrimmedTag := range strings.Split(token.value, ",") {
+		if tagValue := tokens[i]; len(tagValue) > 0 {
+			startOffset := offset + utf8.RuneCountInString(untrimmedTag) - utf8.RuneCountInString(strings.TrimLeftFunc(untrimmedTag, unicode.IsSpace))
 			token.args = append(token.args, tagValue)
+			token.argSpans = append(token.argSpans, token.valueSpan(startOffset, startOffset+utf8.RuneCountInString(tagValue)-1))
 		}
+		offset += utf8.RuneCountInString(untrimmedTag) + 1
 	}
 	return nil, false
 }
@@ -289,7 +589,9 @@ func processTable(parser *specParser, token *token) (*parseError, bool) {
 
 	var buffer bytes.Buffer
 	shouldEscape := false
-	for i, element := range token.value {
+	value := []rune(token.value)
+	cellStart := 1
+	for i, element := range value {
 		if i == 0 {
 			continue
 		}
@@ -304,16 +606,21 @@ func processTable(parser *specParser, token *token) (*parseError, bool) {
 		} else if element == '|' {
 			trimmedValue := strings.TrimSpace(buffer.String())
 
-			if token.kind == tableHeader {
+			if token.kind == tableHeader && !token.isVertical {
+				//rows following an invalid header still belong to its table and should not be parsed as headers
 				if len(trimmedValue) == 0 {
+					addStates(&parser.currentState, tableScope)
 					return &parseError{lineNo: parser.lineNo, lineText: token.value, message: "Table header should not be blank"}, true
 				}
 
 				if arrayContains(token.args, trimmedValue) {
+					addStates(&parser.currentState, tableScope)
 					return &parseError{lineNo: parser.lineNo, lineText: token.value, message: "Table header cannot have repeated column values"}, true
 				}
 			}
 			token.args = append(token.args, trimmedValue)
+			token.argSpans = append(token.argSpans, cellSpan(token, value, cellStart, i-1))
+			cellStart = i + 1
 			buffer.Reset()
 		} else {
 			buffer.WriteRune(element)
@@ -321,6 +628,13 @@ func processTable(parser *specParser, token *token) (*parseError, bool) {
 
 	}
 
+	if token.isVertical {
+		if err := parser.addVerticalTableHeader(token); err != nil {
+			addStates(&parser.currentState, tableScope)
+			return err, true
+		}
+	}
+
 	if !isInState(parser.currentState, tableScope) {
 		addStates(&parser.currentState, tableScope)
 	} else {
@@ -330,6 +644,33 @@ func processTable(parser *specParser, token *token) (*parseError, bool) {
 	return nil, false
 }
 
+//every row of a vertical table starts with a header, which has the same checks as the header row of a table
+func (parser *specParser) addVerticalTableHeader(token *token) *parseError {
+	if len(token.args) == 0 || areUnderlined(token.args) {
+		return nil
+	}
+	header := token.args[0]
+	if len(header) == 0 {
+		return &parseError{lineNo: parser.lineNo, lineText: token.value, message: "Table header should not be blank"}
+	}
+	if arrayContains(parser.verticalTableHeaders, header) {
+		return &parseError{lineNo: parser.lineNo, lineText: token.value, message: "Table header cannot have repeated column values"}
+	}
+	parser.verticalTableHeaders = append(parser.verticalTableHeaders, header)
+	return nil
+}
+
+//span of the cell between the given offsets of the row, leaving out the whitespace around the cell value
+func cellSpan(token *token, row []rune, startOffset int, endOffset int) span {
+	for startOffset < endOffset && unicode.IsSpace(row[startOffset]) {
+		startOffset++
+	}
+	for endOffset > startOffset && unicode.IsSpace(row[endOffset]) {
+		endOffset--
+	}
+	return token.valueSpan(startOffset, endOffset)
+}
+
 func (parser *specParser) nextLine() (string, bool) {
 	scanned := parser.scanner.Scan()
 	if scanned {
@@ -68,8 +68,8 @@ func (s *MySuite) TestParsingThrowErrorForEmptySpecHeading(c *C) {
 
 	_, err := parser.generateTokens(specText)
 
-	c.Assert(err, NotNil)
-	c.Assert(err.Error(), Equals, "line no: 1, Spec heading should have at least one character")
+	c.Assert(len(err), Equals, 1)
+	c.Assert(err[0].Error(), Equals, "line no: 1, Spec heading should have at least one character")
 }
 
 func (s *MySuite) TestParsingScenarioHeading(c *C) {
@@ -90,8 +90,8 @@ func (s *MySuite) TestParsingThrowErrorForEmptyScenarioHeading(c *C) {
 
 	_, err := parser.generateTokens(specText)
 
-	c.Assert(err, NotNil)
-	c.Assert(err.Error(), Equals, "line no: 2, Scenario heading should have at least one character")
+	c.Assert(len(err), Equals, 1)
+	c.Assert(err[0].Error(), Equals, "line no: 2, Scenario heading should have at least one character")
 }
 
 func (s *MySuite) TestParsingScenarioWithoutSpecHeading(c *C) {
@@ -305,6 +305,87 @@ func (s *MySuite) TestParseSpecTagsBeforeSpecHeading(c *C) {
 	c.Assert(tokens[0].value, Equals, "tag1")
 }
 
+func (s *MySuite) TestParseMetadata(c *C) {
+	parser := new(specParser)
+	specText := SpecBuilder().text("---").text("owner: qa-team").text("").text("jira : GAUGE-123 ").text("---").specHeading("Spec heading").String()
+
+	tokens, err := parser.generateTokens(specText)
+
+	c.Assert(err, IsNil)
+	c.Assert(len(tokens), Equals, 2)
+	c.Assert(tokens[0].kind, Equals, metadataKind)
+	c.Assert(tokens[0].args, DeepEquals, []string{"owner", "qa-team", "jira", "GAUGE-123"})
+	c.Assert(tokens[0].span, Equals, span{startLine: 1, startColumn: 1, endLine: 5, endColumn: 3})
+	c.Assert(tokens[1].kind, Equals, specKind)
+}
+
+func (s *MySuite) TestMetadataIsParsedOnlyAtTheTopOfTheSpec(c *C) {
+	parser := new(specParser)
+	specText := SpecBuilder().specHeading("Spec heading").text("---").text("owner: qa-team").text("---").String()
+
+	tokens, err := parser.generateTokens(specText)
+
+	c.Assert(err, IsNil)
+	c.Assert(tokens[1].kind, Equals, commentKind)
+}
+
+func (s *MySuite) TestParseInvalidMetadata(c *C) {
+	specText := SpecBuilder().text("---").text("owner: qa-team").text("not a key value pair").text("---").specHeading("Spec heading").String()
+	_, err := new(specParser).generateTokens(specText)
+	c.Assert(len(err), Equals, 1)
+	c.Assert(err[0].lineNo, Equals, 3)
+	c.Assert(err[0].message, Equals, "Metadata should be of the form key: value")
+
+	specText = SpecBuilder().text("---").text("owner: qa-team").text("owner: dev-team").text("---").specHeading("Spec heading").String()
+	_, err = new(specParser).generateTokens(specText)
+	c.Assert(len(err), Equals, 1)
+	c.Assert(err[0].lineNo, Equals, 3)
+	c.Assert(err[0].message, Equals, "Duplicate metadata key owner")
+
+	specText = SpecBuilder().text("---").text("owner: qa-team").specHeading("Spec heading").String()
+	_, err = new(specParser).generateTokens(specText)
+	c.Assert(len(err), Equals, 1)
+	c.Assert(err[0].lineNo, Equals, 1)
+	c.Assert(err[0].message, Equals, "Metadata is not closed")
+}
+
+func (s *MySuite) TestParseSkipMarkers(c *C) {
+	parser := new(specParser)
+	specText := SpecBuilder().specHeading("Spec heading").text("@skip: not ready yet").scenarioHeading("Scenario Heading").text(" @Skip: known issue ").String()
+
+	tokens, err := parser.generateTokens(specText)
+
+	c.Assert(err, IsNil)
+	c.Assert(len(tokens), Equals, 4)
+	c.Assert(tokens[1].kind, Equals, skipKind)
+	c.Assert(tokens[1].value, Equals, "not ready yet")
+	c.Assert(tokens[3].kind, Equals, skipKind)
+	c.Assert(tokens[3].value, Equals, "known issue")
+}
+
+func (s *MySuite) TestCommentStartingWithSkipIsNotASkipMarker(c *C) {
+	specText := SpecBuilder().specHeading("Spec heading").scenarioHeading("Scenario Heading").text("Skip: the login when already signed in").text("skip : to the end").step("a step").String()
+
+	spec, result := new(specParser).parse(specText, new(conceptDictionary))
+
+	c.Assert(result.ok, Equals, true)
+	c.Assert(spec.scenarios[0].skip, IsNil)
+	c.Assert(len(spec.scenarios[0].comments), Equals, 2)
+	c.Assert(spec.scenarios[0].comments[0].value, Equals, "Skip: the login when already signed in")
+	c.Assert(len(spec.scenarios[0].steps), Equals, 1)
+}
+
+func (s *MySuite) TestParseSkipMarkerWithoutReason(c *C) {
+	parser := new(specParser)
+	specText := SpecBuilder().specHeading("Spec heading").scenarioHeading("Scenario Heading").text("@skip:").step("a step").String()
+
+	_, err := parser.generateTokens(specText)
+
+	c.Assert(len(err), Equals, 1)
+	c.Assert(err[0].lineNo, Equals, 3)
+	c.Assert(err[0].message, Equals, "Reason for skipping should not be blank")
+}
+
 func (s *MySuite) TestParsingSimpleDataTable(c *C) {
 	parser := new(specParser)
 	specText := SpecBuilder().specHeading("Spec heading").text("|name|id|").text("|---|---|").text("|john|123|").text("|james|007|").String()
@@ -422,8 +503,8 @@ func (s *MySuite) TestParsingDataTableThrowsErrorWithEmptyHeader(c *C) {
 	specText := SpecBuilder().specHeading("Spec heading").text("| name|id |||").text("| escape \\| pipe |second|third|second|").String()
 
 	_, err := parser.generateTokens(specText)
-	c.Assert(err, NotNil)
-	c.Assert(err.Error(), Equals, "line no: 2, Table header should not be blank")
+	c.Assert(len(err), Equals, 1)
+	c.Assert(err[0].Error(), Equals, "line no: 2, Table header should not be blank")
 }
 
 func (s *MySuite) TestParsingDataTableThrowsErrorWithSameColumnHeader(c *C) {
@@ -431,8 +512,8 @@ func (s *MySuite) TestParsingDataTableThrowsErrorWithSameColumnHeader(c *C) {
 	specText := SpecBuilder().specHeading("Spec heading").text("| name|id|name|").text("|1|2|3|").String()
 
 	_, err := parser.generateTokens(specText)
-	c.Assert(err, NotNil)
-	c.Assert(err.Error(), Equals, "line no: 2, Table header cannot have repeated column values")
+	c.Assert(len(err), Equals, 1)
+	c.Assert(err[0].Error(), Equals, "line no: 2, Table header cannot have repeated column values")
 }
 
 func (s *MySuite) TestParsingDataTableWithSeparatorAsHeader(c *C) {
@@ -519,8 +600,9 @@ func (s *MySuite) TestParsingConceptInSpec(c *C) {
 	step2 := &step{value: "step 2"}
 	concept1 := &step{value: "concept step", conceptSteps: []*step{step1, step2}, isConcept: true}
 	err := conceptDictionary.add([]*step{concept1}, "file.cpt")
-	tokens, err := parser.generateTokens(specText)
 	c.Assert(err, IsNil)
+	tokens, errs := parser.generateTokens(specText)
+	c.Assert(errs, IsNil)
 	spec, parseResult := parser.createSpecification(tokens, conceptDictionary)
 
 	c.Assert(parseResult.ok, Equals, true)
@@ -537,7 +619,8 @@ func (s *MySuite) TestTableFromInvalidFile(c *C) {
 	specText := SpecBuilder().specHeading("Spec heading").text("table: inputinvalid.csv").String()
 
 	_, err := parser.generateTokens(specText)
-	c.Assert(err.message, Equals, "Could not resolve table from table: inputinvalid.csv")
+	c.Assert(len(err), Equals, 1)
+	c.Assert(err[0].message, Equals, "Could not resolve table from table: inputinvalid.csv")
 }
 
 func (s *MySuite) TestTableInputFromInvalidFileAndDataTableNotInitialized(c *C) {
@@ -558,6 +641,47 @@ func (s *MySuite) TestTableInputFromFile(c *C) {
 	c.Assert(parseRes.ok, Equals, false)
 }
 
+func (s *MySuite) TestJsonAndYamlDataTableTokens(c *C) {
+	parser := new(specParser)
+
+	value, found := parser.isDataTable("table: JSON : fixtures/Users.json")
+	c.Assert(found, Equals, true)
+	c.Assert(value, Equals, "table: json: fixtures/Users.json")
+	c.Assert(dataTableParam(value), Equals, "json: fixtures/Users.json")
+
+	value, found = parser.isDataTable("Table:yaml:users.yml")
+	c.Assert(found, Equals, true)
+	c.Assert(value, Equals, "table: yaml: users.yml")
+	c.Assert(dataTableParam(value), Equals, "yaml: users.yml")
+
+	value, found = parser.isDataTable("table: users.csv")
+	c.Assert(found, Equals, true)
+	c.Assert(dataTableParam(value), Equals, "table: users.csv")
+
+	_, found = parser.isDataTable("json: users.json")
+	c.Assert(found, Equals, false)
+}
+
+func (s *MySuite) TestJsonLineInSpecDescriptionIsAComment(c *C) {
+	parser := new(specParser)
+	specText := SpecBuilder().specHeading("Spec heading").text("json: is the format of the fixtures").
+		scenarioHeading("Scenario heading").step("a step").String()
+
+	spec, parseRes := parser.parse(specText, new(conceptDictionary))
+	c.Assert(parseRes.ok, Equals, true)
+	c.Assert(spec.dataTable.isInitialized(), Equals, false)
+	c.Assert(spec.comments[0].value, Equals, "json: is the format of the fixtures")
+}
+
+func (s *MySuite) TestJsonDataTableFromInvalidFile(c *C) {
+	parser := new(specParser)
+	specText := SpecBuilder().specHeading("Spec heading").text("table: json: inputinvalid.json").String()
+
+	_, parseRes := parser.parse(specText, new(conceptDictionary))
+	c.Assert(parseRes.error.message, Equals, "Could not resolve table from table: json: inputinvalid.json")
+	c.Assert(parseRes.ok, Equals, false)
+}
+
 func (s *MySuite) TestTableInputFromFileIfPathNotSpecified(c *C) {
 	parser := new(specParser)
 	specText := SpecBuilder().specHeading("Spec heading").text("Table: ").String()
@@ -566,3 +690,202 @@ func (s *MySuite) TestTableInputFromFileIfPathNotSpecified(c *C) {
 	c.Assert(parseRes.error.message, Equals, "Table location not specified")
 	c.Assert(parseRes.ok, Equals, false)
 }
+
+func (s *MySuite) TestParsingTearDownSeparator(c *C) {
+	parser := new(specParser)
+	specText := SpecBuilder().specHeading("Spec heading").scenarioHeading("Scenario").step("Example step").text("___").step("Clean up").String()
+
+	tokens, err := parser.generateTokens(specText)
+	c.Assert(err, IsNil)
+	c.Assert(len(tokens), Equals, 5)
+
+	c.Assert(tokens[3].kind, Equals, tearDownKind)
+	c.Assert(tokens[3].value, Equals, "___")
+	c.Assert(tokens[4].kind, Equals, stepKind)
+	c.Assert(tokens[4].value, Equals, "Clean up")
+}
+
+func (s *MySuite) TestShortUnderscoreLineIsNotATearDownSeparator(c *C) {
+	parser := new(specParser)
+	specText := SpecBuilder().specHeading("Spec heading").text("__").String()
+
+	tokens, err := parser.generateTokens(specText)
+	c.Assert(err, IsNil)
+	c.Assert(len(tokens), Equals, 2)
+	c.Assert(tokens[1].kind, Equals, commentKind)
+}
+
+func (s *MySuite) TestUnderscoreLinesOtherThanTheSeparatorAfterScenariosAreComments(c *C) {
+	specText := SpecBuilder().specHeading("Spec heading").text("___").step("context step").
+		scenarioHeading("Scenario").step("Example step").text("_____").step("Another step").String()
+
+	spec, result := new(specParser).parse(specText, new(conceptDictionary))
+
+	c.Assert(result.ok, Equals, true)
+	c.Assert(len(spec.contexts), Equals, 1)
+	c.Assert(spec.comments[0].value, Equals, "___")
+	c.Assert(len(spec.tearDownSteps), Equals, 0)
+	c.Assert(len(spec.scenarios[0].steps), Equals, 2)
+	c.Assert(spec.scenarios[0].comments[0].value, Equals, "_____")
+}
+
+func (s *MySuite) TestParsingTextBlockUnderStep(c *C) {
+	parser := new(specParser)
+	specText := SpecBuilder().specHeading("Spec heading").scenarioHeading("Scenario").step("Post payload").
+		text("```json").text("{").text("  \"id\": 1").text("}").text("```").step("Next step").String()
+
+	tokens, err := parser.generateTokens(specText)
+	c.Assert(err, IsNil)
+	c.Assert(len(tokens), Equals, 5)
+
+	c.Assert(tokens[3].kind, Equals, textBlockKind)
+	c.Assert(tokens[3].lineNo, Equals, 4)
+	c.Assert(tokens[3].lineText, Equals, "```json")
+	c.Assert(tokens[3].value, Equals, "{\n  \"id\": 1\n}")
+	c.Assert(tokens[4].kind, Equals, stepKind)
+}
+
+func (s *MySuite) TestFenceNotUnderStepIsAComment(c *C) {
+	parser := new(specParser)
+	specText := SpecBuilder().specHeading("Spec heading").text("```").text("some code").text("```").String()
+
+	tokens, err := parser.generateTokens(specText)
+	c.Assert(err, IsNil)
+	c.Assert(len(tokens), Equals, 4)
+	c.Assert(tokens[1].kind, Equals, commentKind)
+	c.Assert(tokens[2].kind, Equals, commentKind)
+	c.Assert(tokens[3].kind, Equals, commentKind)
+}
+
+func (s *MySuite) TestErrorWhenTextBlockIsNotClosed(c *C) {
+	parser := new(specParser)
+	specText := SpecBuilder().specHeading("Spec heading").scenarioHeading("Scenario").step("Run query").text("```sql").text("select 1").String()
+
+	_, err := parser.generateTokens(specText)
+	c.Assert(len(err), Equals, 1)
+	c.Assert(err[0].message, Equals, "Text block is not closed")
+	c.Assert(err[0].lineNo, Equals, 4)
+}
+
+func (s *MySuite) TestParsingReportsTokenAndConversionErrorsSortedByLine(c *C) {
+	parser := new(specParser)
+	specText := SpecBuilder().specHeading("Spec heading").
+		scenarioHeading("Scenario").
+		step("step with <unknown>").
+		step("step with \"unterminated").
+		step("valid step").
+		scenarioHeading("").String()
+
+	spec, result := parser.parse(specText, new(conceptDictionary))
+
+	c.Assert(spec, IsNil)
+	c.Assert(result.ok, Equals, false)
+	c.Assert(len(result.errors), Equals, 3)
+	c.Assert(result.errors[0].Error(), Equals, "line no: 3, Dynamic parameter <unknown> could not be resolved")
+	c.Assert(result.errors[1].Error(), Equals, "line no: 4, String not terminated")
+	c.Assert(result.errors[2].Error(), Equals, "line no: 6, Scenario heading should have at least one character")
+	c.Assert(result.error, Equals, result.errors[0])
+}
+
+func (s *MySuite) TestParseResultErrorListsAllErrors(c *C) {
+	result := &parseResult{fileName: "foo.spec"}
+	result.addErrors(&parseError{lineNo: 5, message: "second"}, &parseError{lineNo: 2, message: "first"})
+
+	c.Assert(result.ok, Equals, false)
+	c.Assert(result.Error(), Equals, "[ParseError] foo.spec : line no: 2, first\n[ParseError] foo.spec : line no: 5, second")
+}
+
+func (s *MySuite) TestSpansOfStepAndItsParameters(c *C) {
+	parser := new(specParser)
+	specText := "# Spec heading\n## Scenario\n  * step with \"static\" and <dyn>  \n"
+
+	tokens, err := parser.generateTokens(specText)
+
+	c.Assert(err, IsNil)
+	stepToken := tokens[2]
+	c.Assert(stepToken.span, Equals, span{startLine: 3, startColumn: 3, endLine: 3, endColumn: 32})
+	c.Assert(len(stepToken.argSpans), Equals, 2)
+	c.Assert(stepToken.argSpans[0], Equals, span{startLine: 3, startColumn: 15, endLine: 3, endColumn: 22})
+	c.Assert(stepToken.argSpans[1], Equals, span{startLine: 3, startColumn: 28, endLine: 3, endColumn: 32})
+}
+
+func (s *MySuite) TestSpansOfTagsAndTableCells(c *C) {
+	parser := new(specParser)
+	specText := "# Spec heading\ntags: foo,  bar baz \n|id | name |\n|1|  \\| escaped|\n"
+
+	tokens, err := parser.generateTokens(specText)
+
+	c.Assert(err, IsNil)
+	c.Assert(tokens[1].argSpans, DeepEquals, []span{span{2, 7, 2, 9}, span{2, 13, 2, 19}})
+	c.Assert(tokens[2].argSpans, DeepEquals, []span{span{3, 2, 3, 3}, span{3, 7, 3, 10}})
+	c.Assert(tokens[3].args, DeepEquals, []string{"1", "| escaped"})
+	c.Assert(tokens[3].argSpans, DeepEquals, []span{span{4, 2, 4, 2}, span{4, 6, 4, 15}})
+}
+
+func (s *MySuite) TestSpanOfUnderlinedHeadingAndTextBlock(c *C) {
+	parser := new(specParser)
+	specText := "Spec heading\n============\n## Scenario\n* step\n```\nsome text\n  ```\n"
+
+	tokens, err := parser.generateTokens(specText)
+
+	c.Assert(err, IsNil)
+	c.Assert(tokens[0].kind, Equals, specKind)
+	c.Assert(tokens[0].span, Equals, span{startLine: 1, startColumn: 1, endLine: 2, endColumn: 12})
+	c.Assert(tokens[3].kind, Equals, textBlockKind)
+	c.Assert(tokens[3].span, Equals, span{startLine: 5, startColumn: 1, endLine: 7, endColumn: 5})
+}
+
+func (s *MySuite) TestSpansAreAddedToParsedSpec(c *C) {
+	parser := new(specParser)
+	specText := "# Spec heading\n|id|\n|1|\n## Scenario\n* step <id>\n   |name|\n   |<id>|\n"
+
+	spec, result := parser.parse(specText, new(conceptDictionary))
+
+	c.Assert(result.ok, Equals, true)
+	c.Assert(spec.heading.span, Equals, span{1, 1, 1, 14})
+	c.Assert(spec.dataTable.table.headerSpans, DeepEquals, []span{span{2, 2, 2, 3}})
+	c.Assert(spec.dataTable.table.get("id")[0].span, Equals, span{3, 2, 3, 2})
+	c.Assert(spec.scenarios[0].heading.span, Equals, span{4, 1, 4, 11})
+	step := spec.scenarios[0].steps[0]
+	c.Assert(step.span, Equals, span{5, 1, 5, 11})
+	c.Assert(step.args[0].span, Equals, span{5, 8, 5, 11})
+	c.Assert(step.args[1].span, Equals, span{6, 4, 7, 9})
+	c.Assert(step.args[1].table.headerSpans, DeepEquals, []span{span{6, 5, 6, 8}})
+	c.Assert(step.args[1].table.get("name")[0].span, Equals, span{7, 5, 7, 8})
+}
+
+func (s *MySuite) TestParsingVerticalTableMarker(c *C) {
+	parser := new(specParser)
+	specText := SpecBuilder().specHeading("Spec heading").scenarioHeading("Scenario").step("create user").text("orientation: vertical").tableHeader("name", "foo").tableRow("age", "20").String()
+
+	tokens, err := parser.generateTokens(specText)
+
+	c.Assert(err, IsNil)
+	c.Assert(len(tokens), Equals, 5)
+	c.Assert(tokens[3].kind, Equals, tableHeader)
+	c.Assert(tokens[3].isVertical, Equals, true)
+	c.Assert(tokens[4].kind, Equals, tableRow)
+	c.Assert(tokens[4].isVertical, Equals, true)
+}
+
+func (s *MySuite) TestOrientationMarkerWithoutTableIsAComment(c *C) {
+	parser := new(specParser)
+	specText := SpecBuilder().specHeading("Spec heading").scenarioHeading("Scenario").text("orientation: vertical").step("create user").tableHeader("name", "age").String()
+
+	tokens, err := parser.generateTokens(specText)
+
+	c.Assert(err, IsNil)
+	c.Assert(tokens[2].kind, Equals, commentKind)
+	c.Assert(tokens[4].kind, Equals, tableHeader)
+	c.Assert(tokens[4].isVertical, Equals, false)
+}
+
+func (s *MySuite) TestErrorOnRepeatedHeaderInVerticalTable(c *C) {
+	parser := new(specParser)
+	specText := SpecBuilder().specHeading("Spec heading").scenarioHeading("Scenario").step("create user").text("orientation: transposed").tableHeader("name", "name").tableRow("name", "foo").String()
+
+	_, err := parser.generateTokens(specText)
+
+	c.Assert(len(err), Equals, 1)
+	c.Assert(err[0].message, Equals, "Table header cannot have repeated column values")
+}
@@ -17,6 +17,12 @@
 
 package main
 
+import (
+	"errors"
+	"fmt"
+	"github.com/getgauge/common"
+)
+
 type specsFilter interface {
 	filter([]*specification) []*specification
 }
@@ -25,9 +31,15 @@ type tagsFilter struct {
 	tagExp string
 }
 
+type tableRowsFilter struct {
+	tableRows   string
+	tableFilter string
+}
+
 type specsGroupFilter struct {
 	group       int
 	execStreams int
+	timingsFile string
 }
 
 type specRandomizer struct {
@@ -42,6 +54,26 @@ func (tagsFilter *tagsFilter) filter(specs []*specification) []*specification {
 	return specs
 }
 
+// Drops the table driven specs none of whose data table rows are selected. The rows of every table driven spec
+// are validated here, with or without a table filter, so that an invalid selection is reported before the execution starts.
+func (rowsFilter *tableRowsFilter) filter(specs []*specification) []*specification {
+	filteredSpecs := make([]*specification, 0)
+	for _, spec := range specs {
+		if spec.dataTable.table.getRowCount() == 0 {
+			filteredSpecs = append(filteredSpecs, spec)
+			continue
+		}
+		rows, err := selectDataTableRows(spec, rowsFilter.tableRows, rowsFilter.tableFilter)
+		if err != nil {
+			handleCriticalError(errors.New(fmt.Sprintf("Table rows validation failed. %s\n", err.Error())))
+		}
+		if len(rows) > 0 {
+			filteredSpecs = append(filteredSpecs, spec)
+		}
+	}
+	return filteredSpecs
+}
+
 func (groupFilter *specsGroupFilter) filter(specs []*specification) []*specification {
 	if groupFilter.group == -1 {
 		return specs
@@ -50,6 +82,14 @@ func (groupFilter *specsGroupFilter) filter(specs []*specification) []*specifica
 	if groupFilter.group < 1 || groupFilter.group > groupFilter.execStreams {
 		return make([]*specification, 0)
 	}
+	// The timings are an explicit input, so that every machine running a group splits the specs the same way
+	if groupFilter.timingsFile != "" {
+		if !common.FileExists(groupFilter.timingsFile) {
+			handleCriticalError(errors.New(fmt.Sprintf("Spec timings file %s not found.", groupFilter.timingsFile)))
+		}
+		timings := loadSpecTimings(groupFilter.timingsFile)
+		return timings.distributeSpecs(specs, groupFilter.execStreams)[groupFilter.group-1].specs
+	}
 	execution := &parallelSpecExecution{specifications: specs}
 	return execution.distributeSpecs(groupFilter.execStreams)[groupFilter.group-1].specs
 }
@@ -76,22 +76,39 @@ func processStep(parser *specParser, token *token) (*parseError, bool) {
 		return &parseError{lineNo: token.lineNo, lineText: token.lineText, message: "Step should not be blank"}, true
 	}
 
-	stepValue, args, err := processStepText(token.value)
+	stepValue, args, argOffsets, err := parseStepText(token.value)
 	if err != nil {
 		return &parseError{lineNo: token.lineNo, lineText: token.lineText, message: err.Error()}, true
 	}
 
 	token.value = stepValue
 	token.args = args
+	token.argSpans = make([]span, 0)
+	for _, offset := range argOffsets {
+		token.argSpans = append(token.argSpans, token.valueSpan(offset.start, offset.end))
+	}
 	parser.clearState()
 	return nil, false
 }
 
+//rune offsets of a parameter in the step text, from its opening to its closing delimiter
+type argOffset struct {
+	start int
+	end   int
+}
+
 func processStepText(text string) (string, []string, error) {
+	stepValue, args, _, err := parseStepText(text)
+	return stepValue, args, err
+}
+
+func parseStepText(text string) (string, []string, []argOffset, error) {
 	reservedChars := map[rune]struct{}{'{': {}, '}': {}}
 	var stepValue, argText bytes.Buffer
 
 	var args []string
+	var argOffsets []argOffset
+	position, argStart := 0, 0
 
 	curBuffer := func(state int) *bytes.Buffer {
 		if isInAnyState(state, inQuotes, inDynamicParam) {
@@ -107,6 +124,7 @@ func processStepText(text string) (string, []string, error) {
 	acceptStaticParam := simpleAcceptor(rune(quotes), rune(quotes), func(int) {
 		stepValue.WriteString("{static}")
 		args = append(args, argText.String())
+		argOffsets = append(argOffsets, argOffset{argStart, position})
 		argText.Reset()
 	}, inQuotes)
 
@@ -122,11 +140,13 @@ func processStepText(text string) (string, []string, error) {
 			stepValue.WriteString("{dynamic}")
 		}
 		args = append(args, argText.String())
+		argOffsets = append(argOffsets, argOffset{argStart, position})
 		argText.Reset()
 	}, inDynamicParam)
 
 	var inParamBoundary bool
-	for _, element := range text {
+	for i, element := range []rune(text) {
+		position = i
 		if currentState == inEscape {
 			currentState = lastState
 			element = getEscapedRuneIfValid(element)
@@ -135,11 +155,13 @@ func processStepText(text string) (string, []string, error) {
 			currentState = inEscape
 			continue
 		} else if currentState, inParamBoundary = acceptSpecialDynamicParam(element, currentState); inParamBoundary {
+			argStart = position
 			continue
 		} else if currentState, inParamBoundary = acceptStaticParam(element, currentState); inParamBoundary {
+			argStart = position
 			continue
 		} else if _, isReservedChar := reservedChars[element]; currentState == inDefault && isReservedChar {
-			return "", nil, errors.New(fmt.Sprintf("'%c' is a reserved character and should be escaped", element))
+			return "", nil, nil, errors.New(fmt.Sprintf("'%c' is a reserved character and should be escaped", element))
 		}
 
 		curBuffer(currentState).WriteRune(element)
@@ -147,12 +169,12 @@ func processStepText(text string) (string, []string, error) {
 
 	// If it is a valid step, the state should be default when the control reaches here
 	if currentState == inQuotes {
-		return "", nil, errors.New(fmt.Sprintf("String not terminated"))
+		return "", nil, nil, errors.New(fmt.Sprintf("String not terminated"))
 	} else if isInState(currentState, inDynamicParam) {
-		return "", nil, errors.New(fmt.Sprintf("Dynamic parameter not terminated"))
+		return "", nil, nil, errors.New(fmt.Sprintf("Dynamic parameter not terminated"))
 	}
 
-	return strings.TrimSpace(stepValue.String()), args, nil
+	return strings.TrimSpace(stepValue.String()), args, argOffsets, nil
 
 }
 
@@ -39,8 +39,8 @@ func (s *MySuite) TestParsingEmptyStepTextShouldThrowError(c *C) {
 
 	_, err := parser.generateTokens(specText)
 
-	c.Assert(err, NotNil)
-	c.Assert(err.Error(), Equals, "line no: 3, Step should not be blank")
+	c.Assert(len(err), Equals, 1)
+	c.Assert(err[0].Error(), Equals, "line no: 3, Step should not be blank")
 }
 
 func (s *MySuite) TestParsingStepWithParams(c *C) {
@@ -89,8 +89,8 @@ func (s *MySuite) TestParsingStepWithUnmatchedOpeningQuote(c *C) {
 
 	_, err := parser.generateTokens(specText)
 
-	c.Assert(err, NotNil)
-	c.Assert(err.Error(), Equals, "line no: 3, String not terminated")
+	c.Assert(len(err), Equals, 1)
+	c.Assert(err[0].Error(), Equals, "line no: 3, String not terminated")
 }
 
 func (s *MySuite) TestParsingStepWithEscaping(c *C) {
@@ -110,8 +110,8 @@ func (s *MySuite) TestParsingExceptionIfStepContainsReservedChars(c *C) {
 
 	_, err := parser.generateTokens(specText)
 
-	c.Assert(err, NotNil)
-	c.Assert(err.Error(), Equals, "line no: 3, '{' is a reserved character and should be escaped")
+	c.Assert(len(err), Equals, 1)
+	c.Assert(err[0].Error(), Equals, "line no: 3, '{' is a reserved character and should be escaped")
 }
 
 func (s *MySuite) TestParsingStepContainsEscapedReservedChars(c *C) {
@@ -145,8 +145,8 @@ func (s *MySuite) TestParsingStepWithUnmatchedDynamicParameterCharacter(c *C) {
 
 	_, err := parser.generateTokens(specText)
 
-	c.Assert(err, NotNil)
-	c.Assert(err.Error(), Equals, "line no: 3, Dynamic parameter not terminated")
+	c.Assert(len(err), Equals, 1)
+	c.Assert(err[0].Error(), Equals, "line no: 3, Dynamic parameter not terminated")
 
 }
 
@@ -27,7 +27,12 @@ type table struct {
 	headerIndexMap map[string]int
 	columns        [][]tableCell
 	headers        []string
+	headerSpans    []span
 	lineNo         int
+	//vertical tables are written with a record in each column, they are kept normalised with the first column as headers
+	isVertical bool
+	//orientation written in the marker of a vertical table, so that it is formatted back the same way
+	orientation string
 }
 
 type dataTable struct {
@@ -40,6 +45,7 @@ type dataTable struct {
 type tableCell struct {
 	value    string
 	cellType argType
+	span     span
 }
 
 func (table *table) isInitialized() bool {
@@ -103,6 +109,17 @@ func (table *table) addRowValues(rowValues []string) {
 	table.addRows(tableCells)
 }
 
+//spans are the positions of the values in the spec file, in the same order as the values
+func (table *table) addRowValuesWithSpans(rowValues []string, spans []span) {
+	tableCells := table.createTableCells(rowValues)
+	for i := range tableCells {
+		if i < len(spans) {
+			tableCells[i].span = spans[i]
+		}
+	}
+	table.addRows(tableCells)
+}
+
 func (table *table) createTableCells(rowValues []string) []tableCell {
 	tableCells := make([]tableCell, 0)
 	for _, value := range rowValues {
@@ -126,11 +143,55 @@ func (table *table) toHeaderSizeRow(rows []tableCell) []tableCell {
 }
 
 func (table *table) addRows(rows []tableCell) {
+	if table.isVertical {
+		table.addVerticalRow(rows)
+		return
+	}
 	for i, value := range table.toHeaderSizeRow(rows) {
 		table.columns[i] = append(table.columns[i], value)
 	}
 }
 
+//a row of a vertical table has a header followed by its value in each record
+func (table *table) addVerticalRow(cells []tableCell) {
+	if len(cells) == 0 {
+		return
+	}
+	if !table.isInitialized() {
+		table.headerIndexMap = make(map[string]int)
+	}
+	recordCount := len(cells) - 1
+	if len(table.columns) > 0 {
+		recordCount = len(table.columns[0])
+	}
+	column := make([]tableCell, 0)
+	for i := 1; i <= recordCount; i++ {
+		if i < len(cells) {
+			column = append(column, cells[i])
+		} else {
+			column = append(column, getDefaultTableCell())
+		}
+	}
+	header := cells[0].getValue()
+	table.headerIndexMap[header] = len(table.headers)
+	table.headers = append(table.headers, header)
+	table.headerSpans = append(table.headerSpans, cells[0].span)
+	table.columns = append(table.columns, column)
+}
+
+//rows of a vertical table as written in the spec, each header followed by its values
+func (table *table) getVerticalRows() [][]string {
+	tableRows := make([][]string, 0)
+	for i, header := range table.headers {
+		row := []string{header}
+		for _, cell := range table.columns[i] {
+			row = append(row, cell.getValue())
+		}
+		tableRows = append(tableRows, row)
+	}
+	return tableRows
+}
+
 func (table *table) getRows() [][]string {
 	if !table.isInitialized() {
 		return nil
@@ -149,6 +210,14 @@ func (table *table) getRows() [][]string {
 	return tableRows
 }
 
+func (table *table) getRowSpans(rowIndex int) []span {
+	rowSpans := make([]span, 0)
+	for _, header := range table.headers {
+		rowSpans = append(rowSpans, table.get(header)[rowIndex].span)
+	}
+	return rowSpans
+}
+
 func (table *table) getRowCount() int {
 	if table.isInitialized() {
 		return len(table.columns[0])
@@ -80,8 +80,8 @@ func (s *MySuite) TestShouldAddRows(c *C) {
 	var table table
 
 	table.addHeaders([]string{"one", "two", "three"})
-	table.addRows([]tableCell{tableCell{"foo", static}, tableCell{"bar", static}, tableCell{"baz", static}})
-	table.addRows([]tableCell{tableCell{"john", static}, tableCell{"jim", static}})
+	table.addRows([]tableCell{tableCell{value: "foo", cellType: static}, tableCell{value: "bar", cellType: static}, tableCell{value: "baz", cellType: static}})
+	table.addRows([]tableCell{tableCell{value: "john", cellType: static}, tableCell{value: "jim", cellType: static}})
 
 	c.Assert(table.getRowCount(), Equals, 2)
 	column1 := table.get("one")
@@ -154,9 +154,9 @@ func (s *MySuite) TestValuesBasedOnHeaders(c *C) {
 	var table table
 	table.addHeaders([]string{"id", "name"})
 
-	firstRow := table.toHeaderSizeRow([]tableCell{tableCell{"123", static}, tableCell{"foo", static}})
-	secondRow := table.toHeaderSizeRow([]tableCell{tableCell{"jim", static}, tableCell{"jack", static}})
-	thirdRow := table.toHeaderSizeRow([]tableCell{tableCell{"789", static}})
+	firstRow := table.toHeaderSizeRow([]tableCell{tableCell{value: "123", cellType: static}, tableCell{value: "foo", cellType: static}})
+	secondRow := table.toHeaderSizeRow([]tableCell{tableCell{value: "jim", cellType: static}, tableCell{value: "jack", cellType: static}})
+	thirdRow := table.toHeaderSizeRow([]tableCell{tableCell{value: "789", cellType: static}})
 
 	c.Assert(len(firstRow), Equals, 2)
 	c.Assert(firstRow[0].value, Equals, "123")
@@ -170,3 +170,13 @@ func (s *MySuite) TestValuesBasedOnHeaders(c *C) {
 	c.Assert(thirdRow[0].value, Equals, "789")
 	c.Assert(thirdRow[1].value, Equals, "")
 }
+
+func (s *MySuite) TestVerticalTableIsNormalisedWithFirstColumnAsHeaders(c *C) {
+	table := &table{isVertical: true}
+	table.addRowValues([]string{"name", "foo", "bar"})
+	table.addRowValues([]string{"email", "foo@b.c"})
+
+	c.Assert(table.headers, DeepEquals, []string{"name", "email"})
+	c.Assert(table.getRows(), DeepEquals, [][]string{[]string{"foo", "foo@b.c"}, []string{"bar", ""}})
+	c.Assert(table.getVerticalRows(), DeepEquals, [][]string{[]string{"name", "foo", "bar"}, []string{"email", "foo@b.c", ""}})
+}
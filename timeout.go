@@ -0,0 +1,138 @@
+// Copyright 2015 ThoughtWorks, Inc.
+
+// This file is part of Gauge.
+
+// Gauge is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+
+// Gauge is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+
+// You should have received a copy of the GNU General Public License
+// along with Gauge.  If not, see <http://www.gnu.org/licenses/>.
+
+package main
+
+import (
+	"fmt"
+	"github.com/getgauge/gauge/config"
+	"github.com/getgauge/gauge/conn"
+	"github.com/getgauge/gauge/gauge_messages"
+	"github.com/getgauge/gauge/logger"
+	"github.com/golang/protobuf/proto"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Tags overriding the configured timeouts in milliseconds. Eg: tags: step_timeout:5000, scenario_timeout:60000
+const (
+	stepTimeoutTag     = "step_timeout"
+	scenarioTimeoutTag = "scenario_timeout"
+)
+
+// Sets the timeouts for the scenario about to be executed. Scenario tags take precedence over
+// spec tags, which take precedence over the configured timeouts.
+func (executor *specExecutor) setTimeouts(scenario *scenario) {
+	executor.stepTimeout = getTimeoutFromTags(stepTimeoutTag, config.StepTimeout(), executor.specification.tags, scenario.tags)
+	executor.scenarioTimeout = getTimeoutFromTags(scenarioTimeoutTag, config.ScenarioTimeout(), executor.specification.tags, scenario.tags)
+	executor.scenarioDeadline = time.Time{}
+	if executor.scenarioTimeout > 0 {
+		executor.scenarioDeadline = time.Now().Add(executor.scenarioTimeout)
+	}
+}
+
+func getTimeoutFromTags(tagName string, defaultTimeout time.Duration, tagsToCheck ...*tags) time.Duration {
+	timeout := defaultTimeout
+	for _, tags := range tagsToCheck {
+		if tags == nil {
+			continue
+		}
+		for _, tag := range tags.values {
+			if !strings.HasPrefix(tag, tagName+":") {
+				continue
+			}
+			value := strings.TrimSpace(strings.TrimPrefix(tag, tagName+":"))
+			milliseconds, err := strconv.Atoi(value)
+			if err != nil {
+				logger.Log.Warning("Incorrect value for %s tag. Cannot convert %s to time", tagName, value)
+				continue
+			}
+			timeout = time.Millisecond * time.Duration(milliseconds)
+		}
+	}
+	return timeout
+}
+
+// Returns the time the current step is allowed to take, along with the error reported when it takes longer.
+func (executor *specExecutor) getStepTimeout() (time.Duration, string) {
+	return executor.getTimeout("Step")
+}
+
+// Returns the time the given action of the current step or hook is allowed to take, along with the error reported when it takes longer.
+func (executor *specExecutor) getTimeout(action string) (time.Duration, string) {
+	timeout, message := executor.stepTimeout, fmt.Sprintf("%s timed out after %s", action, executor.stepTimeout)
+	if !executor.scenarioDeadline.IsZero() {
+		remaining := executor.scenarioDeadline.Sub(time.Now())
+		if executor.stepTimeout <= 0 || remaining < executor.stepTimeout {
+			timeout, message = remaining, fmt.Sprintf("Scenario timed out after %s", executor.scenarioTimeout)
+		}
+	}
+	return timeout, message
+}
+
+// Spec hooks are run outside of any scenario, so only the step timeout of the spec applies to them.
+func (executor *specExecutor) setSpecTimeouts() {
+	executor.stepTimeout = getTimeoutFromTags(stepTimeoutTag, config.StepTimeout(), executor.specification.tags)
+	executor.scenarioTimeout = 0
+	executor.scenarioDeadline = time.Time{}
+}
+
+func (executor *specExecutor) executeStepMessage(message *gauge_messages.Message) *gauge_messages.ProtoExecutionResult {
+	return executor.executeMessage(message, "Step")
+}
+
+func (executor *specExecutor) executeHookMessage(message *gauge_messages.Message) *gauge_messages.ProtoExecutionResult {
+	return executor.executeMessage(message, "Hook")
+}
+
+// Sends the message to the runner, failing it if the runner does not respond within the step timeout.
+// The runner is restarted on a timeout and brought back to the current spec, since a hung runner cannot execute the rest of the scenarios.
+func (executor *specExecutor) executeMessage(message *gauge_messages.Message, action string) *gauge_messages.ProtoExecutionResult {
+	if executor.stepTimeout <= 0 && executor.scenarioDeadline.IsZero() {
+		return executeAndGetStatus(executor.runner, message, executor.writer)
+	}
+	timeout, timeoutMessage := executor.getTimeout(action)
+	if timeout <= 0 {
+		return errorResult(timeoutMessage)
+	}
+	initMessages := []*gauge_messages.Message{newSuiteDataStoreInitMessage(), newExecutionStartingMessage(), newSpecDataStoreInitMessage(), newSpecExecutionStartingMessage(executor.currentExecutionInfo)}
+	return executeWithTimeout(executor.runner, message, timeout, timeoutMessage, executor.writer, initMessages...)
+}
+
+// Sends the message to the runner, failing it with the timeout message if the runner does not respond within the timeout.
+// A runner which does not respond is restarted and sent the init messages.
+func executeWithTimeout(runner *testRunner, message *gauge_messages.Message, timeout time.Duration, timeoutMessage string, writer executionLogger, initMessages ...*gauge_messages.Message) *gauge_messages.ProtoExecutionResult {
+	if runner.restartError != nil {
+		return runnerLostResult(runner)
+	}
+	response, err := conn.GetResponseForMessageWithTimeout(message, runner.connection, timeout)
+	if err == conn.ErrRequestTimeout {
+		writer.Error("%s. Restarting the runner.", timeoutMessage)
+		if err := runner.restart(writer, initMessages...); err != nil {
+			writer.Error("Failed to restart the runner. %s", err.Error())
+		}
+		timeoutResult := errorResult(timeoutMessage)
+		timeoutResult.ExecutionTime = proto.Int64(int64(timeout / time.Millisecond))
+		return timeoutResult
+	}
+	return getExecutionStatus(response, err, writer)
+}
+
+func runnerLostResult(runner *testRunner) *gauge_messages.ProtoExecutionResult {
+	return errorResult(fmt.Sprintf("Failed to restart the runner. %s", runner.restartError.Error()))
+}
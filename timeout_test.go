@@ -0,0 +1,81 @@
+// Copyright 2015 ThoughtWorks, Inc.
+
+// This file is part of Gauge.
+
+// Gauge is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+
+// Gauge is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+
+// You should have received a copy of the GNU General Public License
+// along with Gauge.  If not, see <http://www.gnu.org/licenses/>.
+
+package main
+
+import (
+	"errors"
+	"github.com/getgauge/gauge/gauge_messages"
+	. "gopkg.in/check.v1"
+	"time"
+)
+
+func (s *MySuite) TestGetTimeoutFromTagsDefaultsWhenNoTagIsPresent(c *C) {
+	timeout := getTimeoutFromTags(stepTimeoutTag, time.Second, &tags{values: []string{"smoke"}}, nil)
+
+	c.Assert(timeout, Equals, time.Second)
+}
+
+func (s *MySuite) TestGetTimeoutFromTagsPrefersScenarioTagsOverSpecTags(c *C) {
+	specTags := &tags{values: []string{"step_timeout:5000", "scenario_timeout:60000"}}
+	scenarioTags := &tags{values: []string{"step_timeout:2000"}}
+
+	c.Assert(getTimeoutFromTags(stepTimeoutTag, 0, specTags, scenarioTags), Equals, 2*time.Second)
+	c.Assert(getTimeoutFromTags(scenarioTimeoutTag, 0, specTags, scenarioTags), Equals, time.Minute)
+}
+
+func (s *MySuite) TestGetTimeoutFromTagsIgnoresInvalidValues(c *C) {
+	timeout := getTimeoutFromTags(stepTimeoutTag, time.Second, &tags{values: []string{"step_timeout:abc"}})
+
+	c.Assert(timeout, Equals, time.Second)
+}
+
+func (s *MySuite) TestGetStepTimeoutUsesRemainingScenarioTimeWhenShorter(c *C) {
+	executor := &specExecutor{stepTimeout: time.Hour, scenarioTimeout: time.Minute, scenarioDeadline: time.Now().Add(time.Minute)}
+
+	timeout, message := executor.getStepTimeout()
+
+	c.Assert(timeout <= time.Minute, Equals, true)
+	c.Assert(message, Equals, "Scenario timed out after 1m0s")
+}
+
+func (s *MySuite) TestGetStepTimeoutUsesStepTimeoutWhenShorter(c *C) {
+	executor := &specExecutor{stepTimeout: time.Second, scenarioTimeout: time.Minute, scenarioDeadline: time.Now().Add(time.Minute)}
+
+	timeout, message := executor.getStepTimeout()
+
+	c.Assert(timeout, Equals, time.Second)
+	c.Assert(message, Equals, "Step timed out after 1s")
+}
+
+func (s *MySuite) TestExecuteStepMessageFailsWhenScenarioTimeIsUsedUp(c *C) {
+	executor := &specExecutor{scenarioTimeout: time.Second, scenarioDeadline: time.Now().Add(-time.Millisecond)}
+
+	result := executor.executeStepMessage(&gauge_messages.Message{MessageType: gauge_messages.Message_ExecuteStep.Enum()})
+
+	c.Assert(result.GetFailed(), Equals, true)
+	c.Assert(result.GetErrorMessage(), Equals, "Scenario timed out after 1s")
+}
+
+func (s *MySuite) TestMessagesAreNotSentToARunnerWhichCouldNotBeRestarted(c *C) {
+	runner := &testRunner{restartError: errors.New("Timed out connecting to runner")}
+
+	result := executeAndGetStatus(runner, &gauge_messages.Message{MessageType: gauge_messages.Message_ExecuteStep.Enum()}, newSimpleConsoleWriter())
+
+	c.Assert(result.GetFailed(), Equals, true)
+	c.Assert(result.GetErrorMessage(), Equals, "Failed to restart the runner. Timed out connecting to runner")
+}
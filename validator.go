@@ -72,8 +72,14 @@ func (self *validator) validate() executionValidationErrors {
 	}
 }
 
+//steps of skipped specs and scenarios are not executed, so they are not validated
 func (self *specValidator) validate() []*stepValidationError {
-	self.specification.traverse(self)
+	if self.specification.skip != nil {
+		return make([]*stepValidationError, 0)
+	}
+	specToValidate := self.specification.getCopy()
+	specToValidate.filter(newSkippedScenarioFilter())
+	specToValidate.traverse(self)
 	return self.stepValidationErrors
 }
 
@@ -113,6 +119,18 @@ func (self *specValidator) contextStep(step *step) {
 	self.step(step)
 }
 
+func (self *specValidator) tearDown(tearDown *tearDown) {
+
+}
+
+func (self *specValidator) tearDownStep(step *step) {
+	self.step(step)
+}
+
+func (self *specValidator) specMetadata(metadata *metadata) {
+
+}
+
 func (self *specValidator) specHeading(heading *heading) {
 	self.stepValidationErrors = make([]*stepValidationError, 0)
 }
@@ -125,6 +143,14 @@ func (self *specValidator) scenarioTags(tags *tags) {
 
 }
 
+func (self *specValidator) specSkip(skip *skip) {
+
+}
+
+func (self *specValidator) scenarioSkip(skip *skip) {
+
+}
+
 func (self *specValidator) dataTable(dataTable *table) {
 
 }